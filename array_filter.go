@@ -0,0 +1,298 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
+)
+
+// ArrayOperator array operator enum type.
+type ArrayOperator int
+
+const (
+	// ArrayOperatorOverlap array overlap (&&) enum, the column has any of the values.
+	ArrayOperatorOverlap ArrayOperator = iota
+
+	// ArrayOperatorContains array contains (@>) enum, the column has all of the values.
+	ArrayOperatorContains
+
+	// ArrayOperatorContainedBy array contained by (<@) enum, the column has none other than the values.
+	ArrayOperatorContainedBy
+
+	// ArrayOperatorAny array any (= ANY) enum, the column has the value, any of the values are
+	// matched when there are multiple values.
+	ArrayOperatorAny
+)
+
+// String returns the string presentation for the array operator.
+func (o ArrayOperator) String() string {
+	return [...]string{"overlap", "contains", "containedBy", "any"}[o]
+}
+
+func parseArrayOperator(v string) (ArrayOperator, error) {
+	for _, o := range []ArrayOperator{ArrayOperatorOverlap, ArrayOperatorContains, ArrayOperatorContainedBy, ArrayOperatorAny} {
+		if strings.EqualFold(v, o.String()) {
+			return o, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported array operator %q", v)
+}
+
+// arrayTypes element types allowed for casting array filter values.
+var arrayTypes = map[string]bool{
+	"text":        true,
+	"varchar":     true,
+	"smallint":    true,
+	"integer":     true,
+	"bigint":      true,
+	"numeric":     true,
+	"boolean":     true,
+	"uuid":        true,
+	"date":        true,
+	"timestamptz": true,
+}
+
+// ArrayFilter array column common filter.
+type ArrayFilter struct {
+	column      sqlColumn
+	operator    ArrayOperator
+	elemType    string
+	Values      []interface{} `json:"values,omitempty"`
+	Cardinality *Range        `json:"cardinality,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler.
+func (f *ArrayFilter) UnmarshalJSON(b []byte) error {
+	type alias ArrayFilter
+
+	m1 := alias{}
+	m2 := make([]interface{}, 0)
+	var m3 interface{}
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Values = m1.Values
+		f.Cardinality = m1.Cardinality
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Values = m2
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m3); err == nil {
+		f.Values = []interface{}{m3}
+		return nil
+	}
+
+	return errors.New("[ArrayFilter]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *ArrayFilter) MarshalJSON() ([]byte, error) {
+	type alias ArrayFilter
+
+	if f.Cardinality == nil {
+		return json.Marshal(f.Values)
+	}
+	return json.Marshal((*alias)(f))
+}
+
+// NewArrayFilter initializes a new array filter.
+func NewArrayFilter(column string) *ArrayFilter {
+	return &ArrayFilter{
+		column: sqlColumn{name: column},
+	}
+}
+
+// Column set the column for the array filter.
+func (f *ArrayFilter) Column(column string) *ArrayFilter {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Operator set the operator for the array filter, defaults to overlap.
+func (f *ArrayFilter) Operator(operator ArrayOperator) *ArrayFilter {
+	f.operator = operator
+	return f
+}
+
+// Overlap set overlap (&&) operator for the array filter.
+func (f *ArrayFilter) Overlap() *ArrayFilter {
+	return f.Operator(ArrayOperatorOverlap)
+}
+
+// Contains set contains (@>) operator for the array filter.
+func (f *ArrayFilter) Contains() *ArrayFilter {
+	return f.Operator(ArrayOperatorContains)
+}
+
+// ContainedBy set contained by (<@) operator for the array filter.
+func (f *ArrayFilter) ContainedBy() *ArrayFilter {
+	return f.Operator(ArrayOperatorContainedBy)
+}
+
+// Any set any (= ANY) operator for the array filter.
+func (f *ArrayFilter) Any() *ArrayFilter {
+	return f.Operator(ArrayOperatorAny)
+}
+
+// Type set the element type the values are cast to for the array filter, e.g. "uuid" casts the
+// values to uuid[]. Values are left for Postgres to coerce to the column type when not set.
+func (f *ArrayFilter) Type(elemType string) *ArrayFilter {
+	f.elemType = elemType
+	return f
+}
+
+// Matches set value(s), calling without values sets an empty value list.
+func (f *ArrayFilter) Matches(values ...interface{}) *ArrayFilter {
+	if f.Values == nil {
+		f.Values = make([]interface{}, 0, len(values))
+	}
+	f.Values = append(f.Values, values...)
+	return f
+}
+
+// CardinalityRange set the range of the number of elements for the array filter.
+func (f *ArrayFilter) CardinalityRange(r *Range) *ArrayFilter {
+	f.Cardinality = r
+	return f
+}
+
+func (f *ArrayFilter) buildArray() interface{} {
+	if f.elemType == "" {
+		return types.NewArray(f.Values)
+	}
+	return orm.SafeQuery("?::?[]", types.NewArray(f.Values), types.Safe(f.elemType))
+}
+
+func (f *ArrayFilter) buildValue(value interface{}) interface{} {
+	if f.elemType == "" {
+		return value
+	}
+	return orm.SafeQuery("?::?", value, types.Safe(f.elemType))
+}
+
+// Appender returns parameters for cond group appender. An empty value list matches no rows (FALSE)
+// with any operator.
+func (f *ArrayFilter) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		if f.elemType != "" && !arrayTypes[f.elemType] {
+			return q, fmt.Errorf("[ArrayFilter]: unsupported type %q", f.elemType)
+		}
+		column := buildIdent(f.column)
+		switch {
+		case f.Values == nil:
+		case len(f.Values) == 0:
+			q.Where("FALSE")
+		case f.operator == ArrayOperatorContains:
+			q.Where("? @> ?", column, f.buildArray())
+		case f.operator == ArrayOperatorContainedBy:
+			q.Where("? <@ ?", column, f.buildArray())
+		case f.operator == ArrayOperatorAny:
+			q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+				for _, v := range f.Values {
+					q.WhereOr("? = ANY(?)", f.buildValue(v), column)
+				}
+				return q, nil
+			})
+		default:
+			q.Where("? && ?", column, f.buildArray())
+		}
+		if r := f.Cardinality; r != nil {
+			cardinality := orm.SafeQuery("cardinality(?)", column)
+			if r.Lt != nil {
+				q.Where("? < ?", cardinality, r.Lt)
+			}
+			if r.Lte != nil {
+				q.Where("? <= ?", cardinality, r.Lte)
+			}
+			if r.Gte != nil {
+				q.Where("? >= ?", cardinality, r.Gte)
+			}
+			if r.Gt != nil {
+				q.Where("? > ?", cardinality, r.Gt)
+			}
+		}
+		return q, nil
+	}
+}
+
+// Apply applies the array filter to the query.
+func (f *ArrayFilter) Apply(q *orm.Query) (*orm.Query, error) {
+	var err error
+	q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q, err = f.Appender()(q)
+		return q, err
+	})
+	return q, err
+}
+
+func (f *ArrayFilter) isZero() bool {
+	return f.Values == nil && (f.Cardinality == nil || f.Cardinality.isZero())
+}
+
+func (f *ArrayFilter) bind(opts *tagOptions) error {
+	if err := opts.allow("op", "type"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("op") {
+		operator, err := parseArrayOperator(opts.get("op"))
+		if err != nil {
+			return err
+		}
+		f.Operator(operator)
+	}
+	if opts.has("type") {
+		f.Type(opts.get("type"))
+	}
+	return nil
+}
+
+func (f *ArrayFilter) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	found := false
+	if list := paramList(values, param); len(list) > 0 {
+		f.Values = make([]interface{}, 0, len(list))
+		for _, v := range list {
+			f.Values = append(f.Values, v)
+		}
+		found = true
+	}
+	cardinality := f.Cardinality
+	if cardinality == nil {
+		cardinality = NewRange("")
+	}
+	ok, err := cardinality.decodeValues(param+"[cardinality]", values, opts)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		f.Cardinality = cardinality
+		found = true
+	}
+	return found, nil
+}
+
+func (f *ArrayFilter) encodeValues(param string, values url.Values) error {
+	if f.Values != nil {
+		list := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			list = append(list, fmt.Sprint(v))
+		}
+		values.Set(param, strings.Join(list, ","))
+	}
+	if f.Cardinality != nil {
+		return f.Cardinality.encodeValues(param+"[cardinality]", values)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ArrayFilter", func() {
+
+	type ArrayFilterTestItem struct {
+		Id     int64
+		Tags   []string `pg:",array"`
+		Scores []int    `pg:",array"`
+		Refs   []string `pg:"type:uuid[],array"`
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			f := pgquery.NewArrayFilter("").Matches("a", "b")
+
+			b, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`["a","b"]`))
+		})
+
+		When("cardinality is set", func() {
+			It("should marshal json successfully", func() {
+				f := pgquery.NewArrayFilter("").Matches("a").CardinalityRange(pgquery.NewRange("").GreaterThanEqual(1))
+
+				b, err := json.Marshal(f)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b).To(MatchJSON(`{"values":["a"],"cardinality":{"gte":1}}`))
+			})
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		When("using object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewArrayFilter("")
+
+				err := json.Unmarshal([]byte(`{"values":["a","b"],"cardinality":{"lte":3}}`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewArrayFilter("").Matches("a", "b").CardinalityRange(pgquery.NewRange("").LessThanEqual(3))))
+			})
+		})
+
+		When("using non-object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewArrayFilter("")
+
+				err := json.Unmarshal([]byte(`"a"`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewArrayFilter("").Matches("a")))
+			})
+		})
+	})
+
+	Context("decoding query string", func() {
+		It("should decode and encode query string values", func() {
+			req := struct {
+				Tags *pgquery.ArrayFilter `pgquery:"op=contains"`
+			}{}
+
+			values, err := url.ParseQuery("tags=a,b&tags[cardinality][lt]=5")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Tags).To(Equal(pgquery.NewArrayFilter("").Matches("a", "b").CardinalityRange(pgquery.NewRange("").LessThan(5))))
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded).To(Equal(values))
+
+			q, err := pgquery.Bind(orm.NewQuery(nil, &ArrayFilterTestItem{}), &req)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "array_filter_test_item"."id", "array_filter_test_item"."tags", "array_filter_test_item"."scores", "array_filter_test_item"."refs" FROM "array_filter_test_items" AS "array_filter_test_item" WHERE (("tags" @> '{"a","b"}') AND (cardinality("tags") < 5))`))
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &ArrayFilterTestItem{})
+
+			q, err := pgquery.NewArrayFilter("tags").Matches("a", `b"c`, "d'e").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "array_filter_test_item"."id", "array_filter_test_item"."tags", "array_filter_test_item"."scores", "array_filter_test_item"."refs" FROM "array_filter_test_items" AS "array_filter_test_item" WHERE (("tags" && '{"a","b\"c","d''e"}'))`))
+		})
+
+		When("using typed values", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &ArrayFilterTestItem{})
+
+				q, err := pgquery.NewArrayFilter("scores").ContainedBy().Type("integer").Matches(1, 2).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+				q, err = pgquery.NewArrayFilter("refs").Any().Type("uuid").Matches("8d5e0d0c-6a4f-4b8f-9f7e-1f9f3c2a0b11").Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "array_filter_test_item"."id", "array_filter_test_item"."tags", "array_filter_test_item"."scores", "array_filter_test_item"."refs" FROM "array_filter_test_items" AS "array_filter_test_item" WHERE (("scores" <@ '{1,2}'::integer[])) AND ((('8d5e0d0c-6a4f-4b8f-9f7e-1f9f3c2a0b11'::uuid = ANY("refs"))))`))
+			})
+		})
+
+		When("using an empty array of values", func() {
+			It("should match none with any operator", func() {
+				for _, operator := range []pgquery.ArrayOperator{pgquery.ArrayOperatorOverlap, pgquery.ArrayOperatorContains, pgquery.ArrayOperatorContainedBy, pgquery.ArrayOperatorAny} {
+					f := pgquery.NewArrayFilter("tags").Operator(operator)
+					err := json.Unmarshal([]byte(`[]`), f)
+					Expect(err).ToNot(HaveOccurred())
+
+					for _, f := range []*pgquery.ArrayFilter{f, pgquery.NewArrayFilter("tags").Operator(operator).Matches()} {
+						q := orm.NewQuery(nil, &ArrayFilterTestItem{})
+
+						q, err := f.Apply(q)
+						Expect(err).ToNot(HaveOccurred())
+
+						s := queryString(q)
+						Expect(s).To(Equal(`SELECT "array_filter_test_item"."id", "array_filter_test_item"."tags", "array_filter_test_item"."scores", "array_filter_test_item"."refs" FROM "array_filter_test_items" AS "array_filter_test_item" WHERE ((FALSE))`), operator.String())
+					}
+				}
+			})
+		})
+
+		When("using unsupported type", func() {
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &ArrayFilterTestItem{})
+
+				_, err := pgquery.NewArrayFilter("tags").Type("text[]); --").Matches("a").Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		refs := []string{
+			"8d5e0d0c-6a4f-4b8f-9f7e-1f9f3c2a0b11",
+			"4f1c2d3e-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+		}
+
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*ArrayFilterTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &ArrayFilterTestItem{
+					Tags:   []string{fmt.Sprintf("tag-%d", itemCount), fmt.Sprintf("group-%d", itemCount%2)},
+					Scores: make([]int, 0),
+					Refs:   []string{refs[itemCount%2]},
+				}
+				for score := 1; score <= itemCount; score++ {
+					item.Scores = append(item.Scores, score)
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with overlap", func() {
+			var items []ArrayFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewArrayFilter("tags").Matches("tag-1", "tag-2", "tag-11").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(2))
+		})
+
+		It("works with contains", func() {
+			var items []ArrayFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewArrayFilter("scores").Contains().Type("integer").Matches(7, 8).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+
+		It("works with contained by", func() {
+			var items []ArrayFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewArrayFilter("scores").ContainedBy().Matches(1, 2, 3).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+
+		It("works with any uuid", func() {
+			var items []ArrayFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewArrayFilter("refs").Any().Type("uuid").Matches(refs[0]).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(5))
+		})
+
+		It("works with cardinality", func() {
+			var items []ArrayFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewArrayFilter("scores").CardinalityRange(pgquery.NewRange("").GreaterThan(3).LessThanEqual(5)).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(2))
+		})
+	})
+})
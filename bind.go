@@ -0,0 +1,291 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+const bindTag = "pgquery"
+
+var filterType = reflect.TypeOf((*Filter)(nil)).Elem()
+
+// tagOptions parsed `pgquery` struct tag, e.g. `pgquery:"column=users.name,ci"`.
+type tagOptions struct {
+	column        string
+	expr          bool
+	defaultColumn string
+	options       map[string]string
+}
+
+func parseTagOptions(tag string) *tagOptions {
+	opts := &tagOptions{
+		options: make(map[string]string),
+	}
+	for _, s := range strings.Split(tag, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		kv := strings.SplitN(s, "=", 2)
+		k, v := kv[0], ""
+		if len(kv) == 2 {
+			v = kv[1]
+		}
+		if k == "column" {
+			opts.column = v
+			continue
+		}
+		opts.options[k] = v
+	}
+	return opts
+}
+
+// columnFor returns the tag column, falls back to the current column then the underscored field name.
+func (o *tagOptions) columnFor(current sqlColumn) sqlColumn {
+	switch {
+	case o.column != "":
+		return sqlColumn{name: o.column, expr: o.expr}
+	case current.name != "":
+		return current
+	default:
+		return sqlColumn{name: o.defaultColumn}
+	}
+}
+
+func (o *tagOptions) has(name string) bool {
+	_, ok := o.options[name]
+	return ok
+}
+
+func (o *tagOptions) get(name string) string {
+	return o.options[name]
+}
+
+// allow returns an error when the tag contains option(s) other than the allowed names, or the
+// options common to all filters.
+func (o *tagOptions) allow(names ...string) error {
+	var unknown []string
+	for k := range o.options {
+		allowed := k == "param"
+		for _, name := range names {
+			if k == name {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unsupported tag option(s) %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// binder implemented by filters configurable through the `pgquery` struct tag.
+type binder interface {
+	bind(opts *tagOptions) error
+}
+
+// zeroer implemented by filters that can be left unset.
+type zeroer interface {
+	isZero() bool
+}
+
+// Bind binds the filter fields of the request struct and applies them to the query. See BindFilters.
+func Bind(q *orm.Query, v interface{}) (*orm.Query, error) {
+	filters, err := BindFilters(v)
+	if err != nil {
+		return q, err
+	}
+	return ApplyAll(q, filters...)
+}
+
+// BindFilters binds the filter fields of the request struct using the `pgquery` struct tag and
+// returns the filters in field order. Nil or unset filters are skipped, embedded structs are
+// traversed and fields tagged with `pgquery:"-"` are ignored.
+//
+//	type ListUsersRequest struct {
+//		Name      *pgquery.KeywordSearch `pgquery:"column=users.name,ci"`
+//		CreatedAt *pgquery.DateTimeRange `pgquery:"column=users.created_at"`
+//		Status    *pgquery.Match
+//	}
+//
+// The column defaults to the underscored field name when neither the tag nor the filter specify one.
+func BindFilters(v interface{}) ([]Filter, error) {
+	return bindFilters(v, nil)
+}
+
+// bindFilters binds the filter fields of the request struct, resolve is called before each filter
+// is bound when given.
+func bindFilters(v interface{}, resolve func(field reflect.StructField, f Filter, opts *tagOptions) error) ([]Filter, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("[Bind]: expected a struct, got %T", v)
+	}
+
+	var filters []Filter
+	err := walkFilterFields(rv, func(field reflect.StructField, f Filter, opts *tagOptions) error {
+		if z, ok := f.(zeroer); ok && z.isZero() {
+			return nil
+		}
+		if resolve != nil {
+			if err := resolve(field, f, opts); err != nil {
+				return err
+			}
+		}
+		if b, ok := f.(binder); ok {
+			if err := b.bind(opts); err != nil {
+				return fmt.Errorf("[Bind]: field %s: %w", field.Name, err)
+			}
+		} else if opts.column != "" || len(opts.options) > 0 {
+			return fmt.Errorf("[Bind]: field %s does not support tag options", field.Name)
+		}
+		filters = append(filters, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+// walkFilterFields calls fn for every non-nil filter field of the struct value.
+func walkFilterFields(rv reflect.Value, fn func(field reflect.StructField, f Filter, opts *tagOptions) error) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag, tagged := field.Tag.Lookup(bindTag)
+		if tag == "-" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && !isFilterType(fv.Type()) {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := walkFilterFields(fv, fn); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		values, ok := filterValues(fv)
+		if !ok {
+			if tagged {
+				return fmt.Errorf("[Bind]: field %s is not a filter", field.Name)
+			}
+			continue
+		}
+		for _, f := range values {
+			opts := parseTagOptions(tag)
+			opts.defaultColumn = underscore(field.Name)
+			if err := fn(field, f, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isFilterType(t reflect.Type) bool {
+	return t.Implements(filterType) || reflect.PtrTo(t).Implements(filterType)
+}
+
+// filterValues returns the non-nil filter(s) held by the field value, reports false if the
+// field is not a filter, or a slice of filters.
+func filterValues(fv reflect.Value) ([]Filter, bool) {
+	switch {
+	case fv.Kind() == reflect.Slice && isFilterType(fv.Type().Elem()):
+		var filters []Filter
+		for i := 0; i < fv.Len(); i++ {
+			if f, ok := filterValue(fv.Index(i)); ok && f != nil {
+				filters = append(filters, f)
+			}
+		}
+		return filters, true
+	case isFilterType(fv.Type()):
+		f, ok := filterValue(fv)
+		if !ok || f == nil {
+			return nil, true
+		}
+		return []Filter{f}, true
+	default:
+		return nil, false
+	}
+}
+
+func filterValue(fv reflect.Value) (Filter, bool) {
+	switch fv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if fv.IsNil() {
+			return nil, true
+		}
+	}
+	if fv.Kind() != reflect.Ptr && fv.CanAddr() && fv.Addr().Type().Implements(filterType) {
+		return fv.Addr().Interface().(Filter), true
+	}
+	if fv.CanInterface() {
+		f, ok := fv.Interface().(Filter)
+		return f, ok
+	}
+	return nil, false
+}
+
+// underscore converts "CamelCase" field name to "camel_case" column name, the same way as go-pg.
+func underscore(s string) string {
+	r := make([]byte, 0, len(s)+5)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUpper(c) {
+			if i > 0 && i+1 < len(s) && (isLower(s[i-1]) || isLower(s[i+1])) {
+				r = append(r, '_', toLower(c))
+			} else {
+				r = append(r, toLower(c))
+			}
+		} else {
+			r = append(r, c)
+		}
+	}
+	return string(r)
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+func isLower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func toLower(c byte) byte {
+	if isUpper(c) {
+		return c + 32
+	}
+	return c
+}
@@ -0,0 +1,146 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bind", func() {
+
+	type BindTestItem struct {
+		Id     int64
+		Name   string
+		Age    int
+		Emails []string `pg:",array"`
+	}
+
+	type BindTestPagination struct {
+		Pagination *pgquery.OffsetPagination
+	}
+
+	type BindTestRequest struct {
+		BindTestPagination
+		Name     *pgquery.KeywordSearch `pgquery:"column=bind_test_item.name,ci,matchStart"`
+		Emails   *pgquery.KeywordSearch `pgquery:",array"`
+		Age      *pgquery.Range
+		Id       *pgquery.Match
+		Sort     []*pgquery.Order
+		Internal *pgquery.Match `pgquery:"-"`
+		Page     string
+	}
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &BindTestItem{})
+
+			req := &BindTestRequest{
+				BindTestPagination: BindTestPagination{
+					Pagination: pgquery.NewOffsetPagination().Offset(2, 5),
+				},
+				Name:     pgquery.NewKeywordSearch("").Keyword("NAME"),
+				Emails:   pgquery.NewKeywordSearch("").Keyword("@root"),
+				Age:      pgquery.NewRange("").GreaterThan(5),
+				Sort:     []*pgquery.Order{pgquery.NewOrderDesc("age"), pgquery.NewOrderAsc("id")},
+				Internal: pgquery.NewMatch("name").Matches("internal"),
+			}
+			q, err := pgquery.Bind(q, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "bind_test_item"."id", "bind_test_item"."name", "bind_test_item"."age", "bind_test_item"."emails" FROM "bind_test_items" AS "bind_test_item" WHERE ("bind_test_item"."name" ILIKE 'NAME%') AND (array_to_string("emails", ',') LIKE '%@root%') AND (("age" > 5)) ORDER BY "age" DESC, "id" ASC LIMIT 5 OFFSET 5`))
+		})
+
+		When("binding unmarshalled json", func() {
+			It("should skip unset filters", func() {
+				q := orm.NewQuery(nil, &BindTestItem{})
+
+				req := &BindTestRequest{}
+				err := json.Unmarshal([]byte(`{"Name":"name","Id":["1","2"]}`), req)
+				Expect(err).ToNot(HaveOccurred())
+
+				q, err = pgquery.Bind(q, req)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "bind_test_item"."id", "bind_test_item"."name", "bind_test_item"."age", "bind_test_item"."emails" FROM "bind_test_items" AS "bind_test_item" WHERE ("bind_test_item"."name" ILIKE 'name%') AND ("id" IN ('1','2'))`))
+			})
+		})
+
+		When("tag option is not supported", func() {
+			It("should return error", func() {
+				req := &struct {
+					Age *pgquery.Range `pgquery:"ci"`
+				}{Age: pgquery.NewRange("").GreaterThan(5)}
+
+				_, err := pgquery.BindFilters(req)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("tagged field is not a filter", func() {
+			It("should return error", func() {
+				req := &struct {
+					Age int `pgquery:"column=age"`
+				}{}
+
+				_, err := pgquery.BindFilters(req)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("value is not a struct", func() {
+			It("should return error", func() {
+				_, err := pgquery.BindFilters("name")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*BindTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &BindTestItem{
+					Name:   fmt.Sprintf("name-%d", itemCount),
+					Age:    itemCount,
+					Emails: []string{fmt.Sprintf("email-%d@root", itemCount)},
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with request struct", func() {
+			var items []BindTestItem
+			q := db.Model(&items)
+
+			req := &BindTestRequest{
+				Name: pgquery.NewKeywordSearch("").Keyword("NAME-1"),
+				Age:  pgquery.NewRange("").LessThanEqual(5),
+				Sort: []*pgquery.Order{pgquery.NewOrderDesc("age")},
+			}
+			q, err := pgquery.Bind(q, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(1)) {
+				Expect(items[0].Id).ToNot(BeZero())
+				Expect(items[0].Name).To(Equal("name-1"))
+			}
+		})
+	})
+})
@@ -0,0 +1,335 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// ErrInvalidCursor the cursor is malformed, has been tampered with or does not match the sorters.
+var ErrInvalidCursor = errors.New("[CursorPagination]: invalid cursor")
+
+type cursorPayload struct {
+	Keys   []string      `json:"k"`
+	Values []interface{} `json:"v"`
+}
+
+// CursorPagination keyset (cursor) pagination common filter. The cursor encodes the sort key values
+// of the last (or first, when paging backwards) row of the page and is signed with the secret.
+type CursorPagination struct {
+	secret   []byte
+	sorters  []*Order
+	nullable bool
+	Limit    *int    `json:"limit,omitempty"`
+	After    *string `json:"after,omitempty"`
+	Before   *string `json:"before,omitempty"`
+}
+
+// NewCursorPagination initializes a new cursor pagination filter. The sorters should end with a
+// unique column, e.g. the primary key, so that every row has a distinct position.
+func NewCursorPagination(secret []byte, sorters ...*Order) *CursorPagination {
+	return &CursorPagination{
+		secret:  secret,
+		sorters: sorters,
+	}
+}
+
+// Sort sets the sorter(s) for the cursor pagination filter.
+func (f *CursorPagination) Sort(sorters ...*Order) *CursorPagination {
+	f.sorters = sorters
+	return f
+}
+
+// Nullable set sort key column(s) nullable, rows with NULL sort key values are paged according to
+// the NULLS FIRST / NULLS LAST position of each sorter.
+func (f *CursorPagination) Nullable() *CursorPagination {
+	f.nullable = true
+	return f
+}
+
+// First sets the page size (limit).
+func (f *CursorPagination) First(limit int) *CursorPagination {
+	f.Limit = &limit
+	return f
+}
+
+// AfterCursor pages forwards, after the row encoded in the cursor.
+func (f *CursorPagination) AfterCursor(cursor string) *CursorPagination {
+	f.After = &cursor
+	f.Before = nil
+	return f
+}
+
+// BeforeCursor pages backwards, before the row encoded in the cursor.
+func (f *CursorPagination) BeforeCursor(cursor string) *CursorPagination {
+	f.Before = &cursor
+	f.After = nil
+	return f
+}
+
+// Backward reports whether the filter pages backwards, rows are then selected in reverse sort order
+// and should be reversed by the caller.
+func (f *CursorPagination) Backward() bool {
+	return f.Before != nil
+}
+
+func (f *CursorPagination) keys() []string {
+	keys := make([]string, 0, len(f.sorters))
+	for _, s := range f.sorters {
+		keys = append(keys, s.format())
+	}
+	return keys
+}
+
+func (f *CursorPagination) sign(payload string) string {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Cursor encodes the sort key value(s) of a row, in the same order as the sorters, into a cursor.
+func (f *CursorPagination) Cursor(values ...interface{}) (string, error) {
+	if len(f.secret) <= 0 {
+		return "", errors.New("[CursorPagination]: secret is not specified")
+	}
+	if len(values) != len(f.sorters) {
+		return "", fmt.Errorf("[CursorPagination]: expected %d cursor values, got %d", len(f.sorters), len(values))
+	}
+	vs := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		if t, ok := v.(time.Time); ok {
+			v = t.Format(time.RFC3339Nano)
+		}
+		vs = append(vs, v)
+	}
+	b, err := json.Marshal(cursorPayload{Keys: f.keys(), Values: vs})
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + f.sign(payload), nil
+}
+
+// CursorOf encodes the sort key value(s) of the go-pg model struct into a cursor.
+func (f *CursorPagination) CursorOf(row interface{}) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(row))
+	if v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("[CursorPagination]: expected a struct, got %T", row)
+	}
+	table := orm.GetTable(v.Type())
+	values := make([]interface{}, 0, len(f.sorters))
+	for _, s := range f.sorters {
+		column := s.sqlColumn().name
+		if i := strings.LastIndexByte(column, '.'); i >= 0 {
+			column = column[i+1:]
+		}
+		field, ok := table.FieldsMap[column]
+		if !ok {
+			return "", fmt.Errorf("[CursorPagination]: %s does not have column %q", table.TypeName, column)
+		}
+		values = append(values, field.Value(v).Interface())
+	}
+	return f.Cursor(values...)
+}
+
+func (f *CursorPagination) decode(cursor string) ([]interface{}, error) {
+	if len(f.secret) <= 0 {
+		return nil, errors.New("[CursorPagination]: secret is not specified")
+	}
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(f.sign(parts[0]))) {
+		return nil, ErrInvalidCursor
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var payload cursorPayload
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&payload); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	keys := f.keys()
+	if len(payload.Keys) != len(keys) || len(payload.Values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+	for i := range keys {
+		if payload.Keys[i] != keys[i] {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return payload.Values, nil
+}
+
+// orders returns the sorters in the direction rows are selected.
+func (f *CursorPagination) orders() []*Order {
+	if !f.Backward() {
+		return f.sorters
+	}
+	orders := make([]*Order, 0, len(f.sorters))
+	for _, s := range f.sorters {
+		orders = append(orders, s.reversed())
+	}
+	return orders
+}
+
+// buildRowValue builds the row-value comparison, e.g. "(created_at, id) < (?, ?)". Only valid
+// when all sorters share the same direction and no value is NULL.
+func (f *CursorPagination) buildRowValue(orders []*Order, values []interface{}) (string, []interface{}, bool) {
+	if f.nullable {
+		return "", nil, false
+	}
+	direction := *orders[0].Direction
+	for i, o := range orders {
+		if *o.Direction != direction || values[i] == nil {
+			return "", nil, false
+		}
+	}
+	op := ">"
+	if direction == OrderDirectionDesc {
+		op = "<"
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(orders)), ", ")
+	params := make([]interface{}, 0, len(orders)*2)
+	for _, o := range orders {
+		params = append(params, buildIdent(o.sqlColumn()))
+	}
+	params = append(params, values...)
+	return "(" + placeholders + ") " + op + " (" + placeholders + ")", params, true
+}
+
+// buildAfter builds the condition for rows strictly after the value in the sorter direction.
+func (f *CursorPagination) buildAfter(o *Order, value interface{}) (string, []interface{}) {
+	column := buildIdent(o.sqlColumn())
+	op := ">"
+	if *o.Direction == OrderDirectionDesc {
+		op = "<"
+	}
+	switch {
+	case value == nil && o.nullsFirst():
+		return "? IS NOT NULL", []interface{}{column}
+	case value == nil:
+		return "FALSE", nil
+	case f.nullable && !o.nullsFirst():
+		return "(? " + op + " ? OR ? IS NULL)", []interface{}{column, value, column}
+	default:
+		return "? " + op + " ?", []interface{}{column, value}
+	}
+}
+
+func (f *CursorPagination) buildEqual(o *Order, value interface{}) (string, []interface{}) {
+	if value == nil {
+		return "? IS NULL", []interface{}{buildIdent(o.sqlColumn())}
+	}
+	return "? = ?", []interface{}{buildIdent(o.sqlColumn()), value}
+}
+
+// buildWhere builds the keyset condition, e.g. "(a > ?) OR (a = ? AND b < ?)".
+func (f *CursorPagination) buildWhere(orders []*Order, values []interface{}) (string, []interface{}) {
+	if cond, params, ok := f.buildRowValue(orders, values); ok {
+		return cond, params
+	}
+	var conds []string
+	var params []interface{}
+	for i := range orders {
+		var parts []string
+		for j := 0; j < i; j++ {
+			cond, p := f.buildEqual(orders[j], values[j])
+			parts = append(parts, cond)
+			params = append(params, p...)
+		}
+		cond, p := f.buildAfter(orders[i], values[i])
+		parts = append(parts, cond)
+		params = append(params, p...)
+		conds = append(conds, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(conds, " OR "), params
+}
+
+// Appender returns parameters for cond group appender.
+func (f *CursorPagination) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		orders := f.orders()
+		cursor := f.After
+		if f.Backward() {
+			cursor = f.Before
+		}
+		if cursor != nil && len(orders) > 0 {
+			values, err := f.decode(*cursor)
+			if err != nil {
+				return q, err
+			}
+			cond, params := f.buildWhere(orders, values)
+			q.Where(cond, params...)
+		}
+		for _, o := range orders {
+			q.OrderExpr(o.Appender())
+		}
+		if limit := f.Limit; limit != nil {
+			q.Limit(*limit)
+		}
+		return q, nil
+	}
+}
+
+// Apply applies the cursor pagination filter to the query.
+func (f *CursorPagination) Apply(q *orm.Query) (*orm.Query, error) {
+	return f.Appender()(q)
+}
+
+func (f *CursorPagination) isZero() bool {
+	return f.Limit == nil && f.After == nil && f.Before == nil && len(f.sorters) == 0
+}
+
+func (f *CursorPagination) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	limit, err := paramInt(values, "limit")
+	if err != nil {
+		return false, err
+	}
+	if limit != nil && *limit < 0 {
+		return false, decodeError("limit", errors.New("expected a non-negative integer"))
+	}
+	if limit != nil {
+		f.Limit = limit
+	}
+	after, hasAfter := paramValue(values, "after")
+	before, hasBefore := paramValue(values, "before")
+	switch {
+	case hasAfter && hasBefore:
+		return false, decodeError("before", errors.New("after and before are mutually exclusive"))
+	case hasAfter:
+		f.AfterCursor(after)
+	case hasBefore:
+		f.BeforeCursor(before)
+	}
+	return limit != nil || hasAfter || hasBefore, nil
+}
+
+func (f *CursorPagination) encodeValues(param string, values url.Values) error {
+	if f.Limit != nil {
+		values.Set("limit", strconv.Itoa(*f.Limit))
+	}
+	if f.After != nil {
+		values.Set("after", *f.After)
+	}
+	if f.Before != nil {
+		values.Set("before", *f.Before)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CursorPagination", func() {
+
+	type CursorPaginationTestItem struct {
+		Id    int64
+		Name  string
+		Score *int
+	}
+
+	secret := []byte("secret")
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			f := pgquery.NewCursorPagination(secret).First(10).AfterCursor("cursor")
+
+			b, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`{"limit":10,"after":"cursor"}`))
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		It("should unmarshal json successfully", func() {
+			f := pgquery.NewCursorPagination(secret)
+
+			err := json.Unmarshal([]byte(`{"limit":10,"before":"cursor"}`), f)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(f).To(Equal(pgquery.NewCursorPagination(secret).First(10).BeforeCursor("cursor")))
+			Expect(f.Backward()).To(BeTrue())
+		})
+	})
+
+	Context("decoding query string", func() {
+		It("should decode and encode query string values", func() {
+			req := struct {
+				Page *pgquery.CursorPagination
+			}{
+				Page: pgquery.NewCursorPagination(secret),
+			}
+
+			values, err := url.ParseQuery("limit=10&before=cursor")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Page).To(Equal(pgquery.NewCursorPagination(secret).First(10).BeforeCursor("cursor")))
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded.Encode()).To(Equal("before=cursor&limit=10"))
+		})
+
+		It("should reject both after and before", func() {
+			req := struct {
+				Page *pgquery.CursorPagination
+			}{
+				Page: pgquery.NewCursorPagination(secret),
+			}
+
+			values, err := url.ParseQuery("after=a&before=b")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("encoding cursor", func() {
+		It("should reject tampered cursor", func() {
+			f := pgquery.NewCursorPagination(secret, pgquery.NewOrderAsc("id"))
+
+			cursor, err := f.Cursor(5)
+			Expect(err).ToNot(HaveOccurred())
+
+			q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+			_, err = f.AfterCursor(strings.Replace(cursor, cursor[:4], "eyJr", 1) + "x").Apply(q)
+			Expect(err).To(Equal(pgquery.ErrInvalidCursor))
+		})
+
+		It("should reject cursor signed with another secret", func() {
+			cursor, err := pgquery.NewCursorPagination([]byte("other"), pgquery.NewOrderAsc("id")).Cursor(5)
+			Expect(err).ToNot(HaveOccurred())
+
+			q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+			_, err = pgquery.NewCursorPagination(secret, pgquery.NewOrderAsc("id")).AfterCursor(cursor).Apply(q)
+			Expect(err).To(Equal(pgquery.ErrInvalidCursor))
+		})
+
+		It("should reject cursor of other sorters", func() {
+			cursor, err := pgquery.NewCursorPagination(secret, pgquery.NewOrderAsc("id")).Cursor(5)
+			Expect(err).ToNot(HaveOccurred())
+
+			q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+			_, err = pgquery.NewCursorPagination(secret, pgquery.NewOrderDesc("id")).AfterCursor(cursor).Apply(q)
+			Expect(err).To(Equal(pgquery.ErrInvalidCursor))
+		})
+
+		It("should encode cursor of model struct", func() {
+			f := pgquery.NewCursorPagination(secret, pgquery.NewOrderAsc("name"), pgquery.NewOrderAsc("cursor_pagination_test_item.id"))
+
+			c1, err := f.CursorOf(&CursorPaginationTestItem{Id: 5, Name: "name-5"})
+			Expect(err).ToNot(HaveOccurred())
+			c2, err := f.Cursor("name-5", 5)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(c1).To(Equal(c2))
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate row-value comparison", func() {
+			q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+
+			f := pgquery.NewCursorPagination(secret, pgquery.NewOrderDesc("name"), pgquery.NewOrderDesc("id")).First(10)
+			cursor, err := f.Cursor("name-5", 5)
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err = f.AfterCursor(cursor).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "cursor_pagination_test_item"."id", "cursor_pagination_test_item"."name", "cursor_pagination_test_item"."score" FROM "cursor_pagination_test_items" AS "cursor_pagination_test_item" WHERE (("name", "id") < ('name-5', '5')) ORDER BY "name" DESC, "id" DESC LIMIT 10`))
+		})
+
+		When("paging backwards", func() {
+			It("should generate reversed comparison and order", func() {
+				q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+
+				f := pgquery.NewCursorPagination(secret, pgquery.NewOrderDesc("name"), pgquery.NewOrderDesc("id")).First(10)
+				cursor, err := f.Cursor("name-5", 5)
+				Expect(err).ToNot(HaveOccurred())
+
+				q, err = f.BeforeCursor(cursor).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "cursor_pagination_test_item"."id", "cursor_pagination_test_item"."name", "cursor_pagination_test_item"."score" FROM "cursor_pagination_test_items" AS "cursor_pagination_test_item" WHERE (("name", "id") > ('name-5', '5')) ORDER BY "name" ASC, "id" ASC LIMIT 10`))
+			})
+		})
+
+		When("using mixed directions", func() {
+			It("should generate expanded comparison", func() {
+				q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+
+				f := pgquery.NewCursorPagination(secret, pgquery.NewOrderDesc("name"), pgquery.NewOrderAsc("id"))
+				cursor, err := f.Cursor("name-5", 5)
+				Expect(err).ToNot(HaveOccurred())
+
+				q, err = f.AfterCursor(cursor).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "cursor_pagination_test_item"."id", "cursor_pagination_test_item"."name", "cursor_pagination_test_item"."score" FROM "cursor_pagination_test_items" AS "cursor_pagination_test_item" WHERE (("name" < 'name-5') OR ("name" = 'name-5' AND "id" > '5')) ORDER BY "name" DESC, "id" ASC`))
+			})
+		})
+
+		When("using nullable columns", func() {
+			It("should generate NULL-aware comparison", func() {
+				q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+
+				f := pgquery.NewCursorPagination(secret, pgquery.NewOrderAsc("score"), pgquery.NewOrderAsc("id")).Nullable()
+				cursor, err := f.Cursor(3, 5)
+				Expect(err).ToNot(HaveOccurred())
+
+				q, err = f.AfterCursor(cursor).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "cursor_pagination_test_item"."id", "cursor_pagination_test_item"."name", "cursor_pagination_test_item"."score" FROM "cursor_pagination_test_items" AS "cursor_pagination_test_item" WHERE ((("score" > '3' OR "score" IS NULL)) OR ("score" = '3' AND ("id" > '5' OR "id" IS NULL))) ORDER BY "score" ASC, "id" ASC`))
+			})
+
+			It("should generate NULL cursor value comparison", func() {
+				q := orm.NewQuery(nil, &CursorPaginationTestItem{})
+
+				f := pgquery.NewCursorPagination(secret, pgquery.NewOrderAsc("score"), pgquery.NewOrderAsc("id"))
+				cursor, err := f.Cursor(nil, 5)
+				Expect(err).ToNot(HaveOccurred())
+
+				q, err = f.AfterCursor(cursor).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "cursor_pagination_test_item"."id", "cursor_pagination_test_item"."name", "cursor_pagination_test_item"."score" FROM "cursor_pagination_test_items" AS "cursor_pagination_test_item" WHERE ((FALSE) OR ("score" IS NULL AND "id" > '5')) ORDER BY "score" ASC, "id" ASC`))
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*CursorPaginationTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &CursorPaginationTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+				}
+				if itemCount%3 != 0 {
+					score := itemCount % 4
+					item.Score = &score
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with forward and backward paging", func() {
+			f := pgquery.NewCursorPagination(secret, pgquery.NewOrderDesc("score"), pgquery.NewOrderAsc("id")).Nullable().First(4)
+
+			var ids []int64
+			for page := 0; page < 3; page++ {
+				var items []CursorPaginationTestItem
+				q, err := f.Apply(db.Model(&items))
+				Expect(err).ToNot(HaveOccurred())
+
+				err = q.Select()
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, item := range items {
+					ids = append(ids, item.Id)
+				}
+				if len(items) == 0 {
+					break
+				}
+				cursor, err := f.CursorOf(&items[len(items)-1])
+				Expect(err).ToNot(HaveOccurred())
+				f.AfterCursor(cursor)
+			}
+
+			// NULL scores first (descending), then 3, 2, 2, 1, 1, 0.
+			Expect(ids).To(Equal([]int64{3, 6, 9, 7, 2, 10, 1, 5, 4, 8}))
+
+			var items []CursorPaginationTestItem
+			cursor, err := f.Cursor(2, 10)
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err := f.BeforeCursor(cursor).Apply(db.Model(&items))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(4)) {
+				Expect(items[0].Id).To(Equal(int64(2)))
+				Expect(items[1].Id).To(Equal(int64(7)))
+				Expect(items[2].Id).To(Equal(int64(9)))
+				Expect(items[3].Id).To(Equal(int64(6)))
+			}
+		})
+	})
+})
@@ -7,16 +7,34 @@ package pgquery
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/go-pg/pg/v10/orm"
 	"github.com/go-pg/pg/v10/types"
 )
 
+// dateLayout date only layout, also used to format date column values.
+const dateLayout = "2006-01-02"
+
+// timestampLayout layout used to format timestamp (without time zone) column values.
+const timestampLayout = "2006-01-02 15:04:05.999999999"
+
+// dateTimeTypes column types allowed for casting datetime range values.
+var dateTimeTypes = map[string]bool{
+	"date":        true,
+	"timestamp":   true,
+	"timestamptz": true,
+}
+
 // DateTimeRange common datetime range filter.
 type DateTimeRange struct {
-	column           string
+	column           sqlColumn
 	layouts          []string
+	location         *time.Location
+	dateOnly         bool
+	columnType       string
 	gtMarshalLayout  string
 	gteMarshalLayout string
 	ltMarshalLayout  string
@@ -32,10 +50,11 @@ func (f *DateTimeRange) MarshalJSON() ([]byte, error) {
 	type alias DateTimeRange
 
 	m1 := struct {
-		Gt  string `json:"after,omitempty"`
-		Gte string `json:"from,omitempty"`
-		Lt  string `json:"before,omitempty"`
-		Lte string `json:"to,omitempty"`
+		Gt       string `json:"after,omitempty"`
+		Gte      string `json:"from,omitempty"`
+		Lt       string `json:"before,omitempty"`
+		Lte      string `json:"to,omitempty"`
+		Timezone string `json:"timezone,omitempty"`
 		*alias
 	}{alias: (*alias)(f)}
 
@@ -63,6 +82,9 @@ func (f *DateTimeRange) MarshalJSON() ([]byte, error) {
 		}
 		m1.Lte = f.Lte.Format(f.lteMarshalLayout)
 	}
+	if f.location != nil {
+		m1.Timezone = f.location.String()
+	}
 
 	return json.Marshal(m1)
 }
@@ -72,10 +94,11 @@ func (f *DateTimeRange) UnmarshalJSON(b []byte) error {
 	type alias DateTimeRange
 
 	m1 := struct {
-		Gt  string `json:"after,omitempty"`
-		Gte string `json:"from,omitempty"`
-		Lt  string `json:"before,omitempty"`
-		Lte string `json:"to,omitempty"`
+		Gt       string `json:"after,omitempty"`
+		Gte      string `json:"from,omitempty"`
+		Lt       string `json:"before,omitempty"`
+		Lte      string `json:"to,omitempty"`
+		Timezone string `json:"timezone,omitempty"`
 		*alias
 	}{alias: (*alias)(f)}
 
@@ -88,9 +111,15 @@ func (f *DateTimeRange) UnmarshalJSON(b []byte) error {
 		return errors.New("[DateTimeRange]: unsupported format when unmarshalling json")
 	}
 
+	if m1.Timezone != "" {
+		if err := f.Timezone(m1.Timezone); err != nil {
+			return err
+		}
+	}
+
 	for _, layout := range f.layouts {
 		if f.Gt == nil && m1.Gt != "" {
-			after, err := time.Parse(layout, m1.Gt)
+			after, err := time.ParseInLocation(layout, m1.Gt, f.loc())
 			if err != nil {
 				continue
 			}
@@ -98,7 +127,7 @@ func (f *DateTimeRange) UnmarshalJSON(b []byte) error {
 			f.Gt = &after
 		}
 		if f.Lt == nil && m1.Lt != "" {
-			before, err := time.Parse(layout, m1.Lt)
+			before, err := time.ParseInLocation(layout, m1.Lt, f.loc())
 			if err != nil {
 				continue
 			}
@@ -106,7 +135,7 @@ func (f *DateTimeRange) UnmarshalJSON(b []byte) error {
 			f.Lt = &before
 		}
 		if f.Gte == nil && m1.Gte != "" {
-			from, err := time.Parse(layout, m1.Gte)
+			from, err := time.ParseInLocation(layout, m1.Gte, f.loc())
 			if err != nil {
 				continue
 			}
@@ -114,7 +143,7 @@ func (f *DateTimeRange) UnmarshalJSON(b []byte) error {
 			f.Gte = &from
 		}
 		if f.Lte == nil && m1.Lte != "" {
-			to, err := time.Parse(layout, m1.Lte)
+			to, err := time.ParseInLocation(layout, m1.Lte, f.loc())
 			if err != nil {
 				continue
 			}
@@ -129,7 +158,7 @@ func (f *DateTimeRange) UnmarshalJSON(b []byte) error {
 // NewDateTimeRange initializes a new datetime range filter.
 func NewDateTimeRange(column string, layouts ...string) *DateTimeRange {
 	return &DateTimeRange{
-		column:           column,
+		column:           sqlColumn{name: column},
 		layouts:          append(layouts, time.RFC3339),
 		gtMarshalLayout:  time.RFC3339,
 		gteMarshalLayout: time.RFC3339,
@@ -140,7 +169,7 @@ func NewDateTimeRange(column string, layouts ...string) *DateTimeRange {
 
 // Column sets the column for the datetime range filter.
 func (f *DateTimeRange) Column(column string) *DateTimeRange {
-	f.column = column
+	f.column = sqlColumn{name: column}
 	return f
 }
 
@@ -150,6 +179,51 @@ func (f *DateTimeRange) Layout(layouts ...string) *DateTimeRange {
 	return f
 }
 
+// Location set the time zone for the datetime range filter. Values without a zone offset are parsed
+// in the location, and date or timestamp column values are formatted in it. Defaults to UTC.
+func (f *DateTimeRange) Location(location *time.Location) *DateTimeRange {
+	f.location = location
+	return f
+}
+
+// Timezone set the time zone by IANA name, e.g. "Asia/Kuala_Lumpur", for the datetime range filter.
+func (f *DateTimeRange) Timezone(name string) error {
+	location, err := loadLocation(name)
+	if err != nil {
+		return fmt.Errorf("[DateTimeRange]: %v", err)
+	}
+	f.Location(location)
+	return nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return location, nil
+}
+
+// DateOnly set date only mode for the datetime range filter, values are whole days in the location,
+// e.g. to (lte) includes the whole day. Adds the "2006-01-02" parsing and marshal layout.
+func (f *DateTimeRange) DateOnly() *DateTimeRange {
+	f.dateOnly = true
+	f.layouts = append(f.layouts, dateLayout)
+	for _, layout := range []*string{&f.gtMarshalLayout, &f.gteMarshalLayout, &f.ltMarshalLayout, &f.lteMarshalLayout} {
+		if *layout == time.RFC3339 {
+			*layout = dateLayout
+		}
+	}
+	return f
+}
+
+// Type set the column type the values are cast to for the datetime range filter, one of "date",
+// "timestamp" or "timestamptz". Values are sent as RFC3339 strings without a cast when not set.
+func (f *DateTimeRange) Type(columnType string) *DateTimeRange {
+	f.columnType = columnType
+	return f
+}
+
 // AfterMarshalLayout set marshal layout for after (gt).
 func (f *DateTimeRange) AfterMarshalLayout(layout string) *DateTimeRange {
 	f.gtMarshalLayout = layout
@@ -198,21 +272,186 @@ func (f *DateTimeRange) To(value time.Time) *DateTimeRange {
 	return f
 }
 
+func (f *DateTimeRange) loc() *time.Location {
+	if f.location != nil {
+		return f.location
+	}
+	return time.UTC
+}
+
+// buildBound returns the operator and value for the bound. In date only mode, bounds on non-date
+// columns are whole days in the location, e.g. to (lte) becomes less than the start of the next day.
+func (f *DateTimeRange) buildBound(op string, value time.Time) (string, time.Time) {
+	if !f.dateOnly || f.columnType == "date" {
+		return op, value
+	}
+	value = value.In(f.loc())
+	day := time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, f.loc())
+	switch op {
+	case ">":
+		return ">=", day.AddDate(0, 0, 1)
+	case "<=":
+		return "<", day.AddDate(0, 0, 1)
+	default:
+		return op, day
+	}
+}
+
+// buildValue returns the value formatted and cast for the column type.
+func (f *DateTimeRange) buildValue(value time.Time) interface{} {
+	switch f.columnType {
+	case "date":
+		return orm.SafeQuery("?::date", value.In(f.loc()).Format(dateLayout))
+	case "timestamp":
+		return orm.SafeQuery("?::timestamp", value.In(f.loc()).Format(timestampLayout))
+	case "timestamptz":
+		return orm.SafeQuery("?::timestamptz", value.Format(time.RFC3339Nano))
+	default:
+		return value.Format(time.RFC3339Nano)
+	}
+}
+
 // Appender returns parameters for cond group appender.
 func (f *DateTimeRange) Appender() applyFn {
 	return func(q *orm.Query) (*orm.Query, error) {
-		if f.Lt != nil {
-			q.Where("? < ?", types.Ident(f.column), f.Lt.Format(time.RFC3339Nano))
+		if f.columnType != "" && !dateTimeTypes[f.columnType] {
+			return q, fmt.Errorf("[DateTimeRange]: unsupported type %q", f.columnType)
 		}
-		if f.Lte != nil {
-			q.Where("? <= ?", types.Ident(f.column), f.Lte.Format(time.RFC3339Nano))
+		bounds := []struct {
+			op    string
+			value *time.Time
+		}{
+			{"<", f.Lt},
+			{"<=", f.Lte},
+			{">=", f.Gte},
+			{">", f.Gt},
 		}
-		if f.Gte != nil {
-			q.Where("? >= ?", types.Ident(f.column), f.Gte.Format(time.RFC3339Nano))
-		}
-		if f.Gt != nil {
-			q.Where("? > ?", types.Ident(f.column), f.Gt.Format(time.RFC3339Nano))
+		for _, bound := range bounds {
+			if bound.value == nil {
+				continue
+			}
+			op, value := f.buildBound(bound.op, *bound.value)
+			q.Where("? ? ?", buildIdent(f.column), types.Safe(op), f.buildValue(value))
 		}
 		return q, nil
 	}
 }
+
+// Apply applies the datetime range filter to the query.
+func (f *DateTimeRange) Apply(q *orm.Query) (*orm.Query, error) {
+	var err error
+	q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q, err = f.Appender()(q)
+		return q, err
+	})
+	return q, err
+}
+
+func (f *DateTimeRange) isZero() bool {
+	return f.Gt == nil && f.Gte == nil && f.Lt == nil && f.Lte == nil
+}
+
+func (f *DateTimeRange) bind(opts *tagOptions) error {
+	if err := opts.allow("layout", "tz", "date", "type"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	return f.bindOptions(opts)
+}
+
+// bindOptions applies the tag options shared by bind and decoding, a request time zone is kept.
+func (f *DateTimeRange) bindOptions(opts *tagOptions) error {
+	if layout := opts.get("layout"); layout != "" {
+		f.Layout(layout)
+	}
+	if opts.has("tz") && f.location == nil {
+		if err := f.Timezone(opts.get("tz")); err != nil {
+			return err
+		}
+	}
+	if opts.has("date") && !f.dateOnly {
+		f.DateOnly()
+	}
+	if opts.has("type") {
+		f.Type(opts.get("type"))
+	}
+	return nil
+}
+
+func (f *DateTimeRange) parse(value string) (time.Time, string, error) {
+	for _, layout := range f.layouts {
+		t, err := time.ParseInLocation(layout, value, f.loc())
+		if err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", errors.New("unsupported datetime format")
+}
+
+func (f *DateTimeRange) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	if len(f.layouts) <= 0 {
+		f.layouts = []string{time.RFC3339}
+	}
+	if v, ok := paramValue(values, param+"[timezone]"); ok {
+		location, err := loadLocation(v)
+		if err != nil {
+			return false, decodeError(param+"[timezone]", err)
+		}
+		f.Location(location)
+	}
+	if err := f.bindOptions(opts); err != nil {
+		return false, err
+	}
+	bounds := []struct {
+		key    string
+		value  **time.Time
+		layout *string
+	}{
+		{"after", &f.Gt, &f.gtMarshalLayout},
+		{"from", &f.Gte, &f.gteMarshalLayout},
+		{"before", &f.Lt, &f.ltMarshalLayout},
+		{"to", &f.Lte, &f.lteMarshalLayout},
+	}
+	found := false
+	for _, bound := range bounds {
+		key := param + "[" + bound.key + "]"
+		v, ok := paramValue(values, key)
+		if !ok {
+			continue
+		}
+		t, layout, err := f.parse(v)
+		if err != nil {
+			return false, decodeError(key, err)
+		}
+		*bound.value = &t
+		*bound.layout = layout
+		found = true
+	}
+	return found, nil
+}
+
+func (f *DateTimeRange) encodeValues(param string, values url.Values) error {
+	bounds := []struct {
+		key    string
+		value  *time.Time
+		layout string
+	}{
+		{"after", f.Gt, f.gtMarshalLayout},
+		{"from", f.Gte, f.gteMarshalLayout},
+		{"before", f.Lt, f.ltMarshalLayout},
+		{"to", f.Lte, f.lteMarshalLayout},
+	}
+	for _, bound := range bounds {
+		if bound.value == nil {
+			continue
+		}
+		if bound.layout == "" {
+			return fmt.Errorf("[DateTimeRange]: marshal layout is not specified for %s", bound.key)
+		}
+		values.Set(param+"["+bound.key+"]", bound.value.Format(bound.layout))
+	}
+	if f.location != nil {
+		values.Set(param+"[timezone]", f.location.String())
+	}
+	return nil
+}
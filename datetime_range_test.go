@@ -7,6 +7,7 @@ package pgquery_test
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/go-pg/pg/v10/orm"
@@ -48,6 +49,20 @@ var _ = Describe("DatetimeRange", func() {
 				Expect(b).To(MatchJSON(`{"after":"12:00AM"}`))
 			})
 		})
+
+		When("timezone and date only are set", func() {
+			It("should marshal json with timezone", func() {
+				location, err := time.LoadLocation("Asia/Kuala_Lumpur")
+				Expect(err).ToNot(HaveOccurred())
+
+				f := pgquery.NewDateTimeRange("created_at").Location(location).DateOnly().To(time.Date(2020, 10, 31, 0, 0, 0, 0, location))
+
+				b, err := json.Marshal(f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(b).To(MatchJSON(`{"to":"2020-10-31","timezone":"Asia/Kuala_Lumpur"}`))
+			})
+		})
 	})
 
 	Context("unmarshalling json", func() {
@@ -76,6 +91,60 @@ var _ = Describe("DatetimeRange", func() {
 			})
 		})
 
+		When("timezone is set", func() {
+			It("should parse values in the timezone", func() {
+				location, err := time.LoadLocation("Asia/Kuala_Lumpur")
+				Expect(err).ToNot(HaveOccurred())
+
+				f := pgquery.NewDateTimeRange("created_at").DateOnly()
+
+				err = json.Unmarshal([]byte(`{"to":"2020-10-31","timezone":"Asia/Kuala_Lumpur"}`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f.Lte.Equal(time.Date(2020, 10, 31, 0, 0, 0, 0, location))).To(BeTrue())
+				Expect(f.Lte.Location()).To(Equal(location))
+			})
+
+			It("should return error for unknown timezone", func() {
+				err := json.Unmarshal([]byte(`{"to":"2020-10-31","timezone":"Mars/Olympus_Mons"}`), pgquery.NewDateTimeRange("created_at").DateOnly())
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+	})
+
+	Context("decoding query string", func() {
+		It("should decode and encode query string values", func() {
+			req := struct {
+				CreatedAt *pgquery.DateTimeRange `pgquery:"date,tz=UTC"`
+			}{}
+
+			values, err := url.ParseQuery("created_at[from]=2020-10-01&created_at[to]=2020-10-31&created_at[timezone]=Asia/Kuala_Lumpur")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(req.CreatedAt.Lte.Format(time.RFC3339)).To(Equal("2020-10-31T00:00:00+08:00"))
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded).To(Equal(values))
+		})
+
+		When("using unknown timezone", func() {
+			It("should return error", func() {
+				req := struct {
+					CreatedAt *pgquery.DateTimeRange
+				}{}
+
+				values, err := url.ParseQuery("created_at[to]=2020-10-31T00:00:00Z&created_at[timezone]=Mars/Olympus_Mons")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pgquery.DecodeValues(values, &req)
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 
 	Context("generating sql", func() {
@@ -90,22 +159,85 @@ var _ = Describe("DatetimeRange", func() {
 			s := queryString(q)
 			Expect(s).To(Equal(`SELECT "datetime_range_test_item"."id", "datetime_range_test_item"."name", "datetime_range_test_item"."created_at" FROM "datetime_range_test_items" AS "datetime_range_test_item" WHERE (("created_at" > '2021-01-15T00:00:00Z'))`))
 		})
+
+		When("using date only", func() {
+			location, err := time.LoadLocation("Asia/Kuala_Lumpur")
+			Expect(err).ToNot(HaveOccurred())
+
+			from := time.Date(2020, 10, 1, 0, 0, 0, 0, location)
+			to := time.Date(2020, 10, 31, 0, 0, 0, 0, location)
+
+			It("should generate whole day bounds in the timezone", func() {
+				q := orm.NewQuery(nil, &DatetimeRangeTestItem{})
+
+				q, err := pgquery.NewDateTimeRange("created_at").Location(location).DateOnly().Type("timestamptz").From(from).To(to).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "datetime_range_test_item"."id", "datetime_range_test_item"."name", "datetime_range_test_item"."created_at" FROM "datetime_range_test_items" AS "datetime_range_test_item" WHERE (("created_at" < '2020-11-01T00:00:00+08:00'::timestamptz) AND ("created_at" >= '2020-10-01T00:00:00+08:00'::timestamptz))`))
+			})
+
+			It("should generate exclusive bounds in the timezone", func() {
+				q := orm.NewQuery(nil, &DatetimeRangeTestItem{})
+
+				q, err := pgquery.NewDateTimeRange("created_at").Location(location).DateOnly().After(from).Before(to).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "datetime_range_test_item"."id", "datetime_range_test_item"."name", "datetime_range_test_item"."created_at" FROM "datetime_range_test_items" AS "datetime_range_test_item" WHERE (("created_at" < '2020-10-31T00:00:00+08:00') AND ("created_at" >= '2020-10-02T00:00:00+08:00'))`))
+			})
+
+			It("should generate date bounds for date column", func() {
+				q := orm.NewQuery(nil, &DatetimeRangeTestItem{})
+
+				q, err := pgquery.NewDateTimeRange("created_at").Location(location).DateOnly().Type("date").From(from).To(to).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "datetime_range_test_item"."id", "datetime_range_test_item"."name", "datetime_range_test_item"."created_at" FROM "datetime_range_test_items" AS "datetime_range_test_item" WHERE (("created_at" <= '2020-10-31'::date) AND ("created_at" >= '2020-10-01'::date))`))
+			})
+		})
+
+		When("using timestamp type", func() {
+			It("should generate values in the timezone", func() {
+				location, err := time.LoadLocation("Asia/Kuala_Lumpur")
+				Expect(err).ToNot(HaveOccurred())
+
+				q := orm.NewQuery(nil, &DatetimeRangeTestItem{})
+
+				q, err = pgquery.NewDateTimeRange("created_at").Location(location).Type("timestamp").After(t).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "datetime_range_test_item"."id", "datetime_range_test_item"."name", "datetime_range_test_item"."created_at" FROM "datetime_range_test_items" AS "datetime_range_test_item" WHERE (("created_at" > '2021-01-15 08:00:00'::timestamp))`))
+			})
+		})
+
+		When("using unsupported type", func() {
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &DatetimeRangeTestItem{})
+
+				_, err := pgquery.NewDateTimeRange("created_at").Type("text").After(t).Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*DatetimeRangeTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*DatetimeRangeTestItem)(nil))
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			item := &DatetimeRangeTestItem{
-				Name:      fmt.Sprintf("name-%d", itemCount),
-				CreatedAt: testTime.Add(time.Duration(itemCount) * time.Hour),
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &DatetimeRangeTestItem{
+					Name:      fmt.Sprintf("name-%d", itemCount),
+					CreatedAt: testTime.Add(time.Duration(itemCount) * time.Hour),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
-			Expect(err).ToNot(HaveOccurred())
-		}
+		})
 
 		It("works with after filter", func() {
 			var items []DatetimeRangeTestItem
@@ -211,5 +343,22 @@ var _ = Describe("DatetimeRange", func() {
 				}
 			}
 		})
+
+		It("works with date only in timezone", func() {
+			location, err := time.LoadLocation("America/New_York")
+			Expect(err).ToNot(HaveOccurred())
+
+			var items []DatetimeRangeTestItem
+			q := db.Model(&items)
+
+			// 2020-10-31 01:00 UTC to 03:00 UTC falls on 2020-10-30 in New York.
+			q, err = pgquery.NewDateTimeRange("created_at").Location(location).DateOnly().Type("timestamptz").To(time.Date(2020, 10, 30, 0, 0, 0, 0, location)).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
 	})
 })
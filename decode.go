@@ -0,0 +1,211 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var identRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+var ordersType = reflect.TypeOf([]*Order(nil))
+
+// DecodeError invalid query string parameter error.
+type DecodeError struct {
+	Param string
+	Err   error
+}
+
+// Error returns the error message naming the offending parameter.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("[Decode]: invalid parameter %q: %v", e.Param, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func decodeError(param string, err error) error {
+	return &DecodeError{Param: param, Err: err}
+}
+
+// valuesDecoder implemented by filters decodable from query string values.
+type valuesDecoder interface {
+	decodeValues(param string, values url.Values, opts *tagOptions) (bool, error)
+}
+
+// DecodeValues decodes query string values into the filter fields of the request struct.
+// Parameter names are taken from the `param` tag option, the json tag name or the underscored
+// field name. Nil filter fields are initialized only when a matching parameter is present.
+//
+//	status=a,b                   Match
+//	age[gte]=18                  Range
+//	created_at[from]=2020-01-01  DateTimeRange
+//	sort=-created_at,name        Order, []*Order, Sort
+//	q=foo                        KeywordSearch
+//	page=2&limit=20              OffsetPagination
+//
+// Other filters are decoded from a single JSON (or plain string) parameter value. A Group field
+// must be initialized with a factory, e.g. And().Factory(factory), to be decoded.
+func DecodeValues(values url.Values, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("[Decode]: expected a non-nil struct pointer, got %T", v)
+	}
+	return decodeStruct(values, rv.Elem())
+}
+
+func decodeStruct(values url.Values, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get(bindTag)
+		if tag == "-" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && !isFilterType(fv.Type()) {
+			if fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := decodeStruct(values, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		opts := parseTagOptions(tag)
+		param := paramName(field, opts)
+
+		switch {
+		case fv.Type() == ordersType:
+			orders, err := decodeOrders(param, values)
+			if err != nil {
+				return err
+			}
+			if orders != nil {
+				fv.Set(reflect.ValueOf(orders))
+			}
+		case fv.Kind() == reflect.Ptr && isFilterType(fv.Type()):
+			target := fv
+			if fv.IsNil() {
+				target = reflect.New(fv.Type().Elem())
+			}
+			found, err := decodeFilter(param, values, opts, target.Interface().(Filter))
+			if err != nil {
+				return err
+			}
+			if found && fv.IsNil() {
+				fv.Set(target)
+			}
+		case fv.Kind() == reflect.Struct && fv.CanAddr() && isFilterType(fv.Type()):
+			if _, err := decodeFilter(param, values, opts, fv.Addr().Interface().(Filter)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeFilter(param string, values url.Values, opts *tagOptions, f Filter) (bool, error) {
+	if d, ok := f.(valuesDecoder); ok {
+		return d.decodeValues(param, values, opts)
+	}
+	return decodeJSON(param, values, f)
+}
+
+// decodeJSON decodes the filter from the JSON (or plain string) parameter value.
+func decodeJSON(param string, values url.Values, f Filter) (bool, error) {
+	v, ok := paramValue(values, param)
+	if !ok {
+		return false, nil
+	}
+	b := []byte(v)
+	if !json.Valid(b) {
+		b, _ = json.Marshal(v)
+	}
+	if err := json.Unmarshal(b, f); err != nil {
+		return false, decodeError(param, err)
+	}
+	return true, nil
+}
+
+// paramName returns the query string parameter name for the struct field.
+func paramName(field reflect.StructField, opts *tagOptions) string {
+	if param := opts.get("param"); param != "" {
+		return param
+	}
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return underscore(field.Name)
+}
+
+// paramValue returns the first non-empty value of the parameter.
+func paramValue(values url.Values, key string) (string, bool) {
+	for _, v := range values[key] {
+		if v = strings.TrimSpace(v); v != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// paramList returns the comma separated and repeated values of the parameter.
+func paramList(values url.Values, key string) []string {
+	var list []string
+	for _, v := range values[key] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
+
+// paramInt returns the integer value of the parameter.
+func paramInt(values url.Values, key string) (*int, error) {
+	v, ok := paramValue(values, key)
+	if !ok {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, decodeError(key, errors.New("expected an integer"))
+	}
+	return &i, nil
+}
+
+// decodeOrders decodes the comma separated sort parameter, e.g. "sort=-created_at,name".
+func decodeOrders(param string, values url.Values) ([]*Order, error) {
+	list := paramList(values, param)
+	if len(list) == 0 {
+		return nil, nil
+	}
+	orders := make([]*Order, 0, len(list))
+	for _, s := range list {
+		o := NewOrder("")
+		if err := o.parse(s); err != nil {
+			return nil, decodeError(param, err)
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
@@ -0,0 +1,212 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DecodeValues", func() {
+
+	type DecodeTestItem struct {
+		Id        int64
+		Name      string
+		Status    string
+		Age       int
+		CreatedAt time.Time
+	}
+
+	type DecodeTestRequest struct {
+		Status     *pgquery.Match
+		Age        *pgquery.Range
+		CreatedAt  *pgquery.DateTimeRange `pgquery:"layout=2006-01-02"`
+		Sort       []*pgquery.Order
+		Name       *pgquery.KeywordSearch `pgquery:"param=q,ci"`
+		Pagination *pgquery.OffsetPagination
+	}
+
+	Context("decoding values", func() {
+		It("should decode values successfully", func() {
+			values, err := url.ParseQuery("status=a,b&age[gte]=18&created_at[from]=2020-01-01&sort=-created_at,name&q=foo&page=2&limit=20")
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &DecodeTestRequest{}
+			err = pgquery.DecodeValues(values, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			from, err := time.Parse("2006-01-02", "2020-01-01")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(req.Status.Values).To(Equal([]interface{}{"a", "b"}))
+			Expect(req.Age).To(Equal(pgquery.NewRange("").GreaterThanEqual(18)))
+			Expect(req.CreatedAt.Gte).To(Equal(&from))
+			Expect(req.Sort).To(Equal([]*pgquery.Order{pgquery.NewOrderDesc("created_at"), pgquery.NewOrderAsc("name")}))
+			Expect(req.Name).To(Equal(pgquery.NewKeywordSearch("").Keyword("foo")))
+			Expect(req.Pagination).To(Equal(pgquery.NewOffsetPagination().Offset(2, 20)))
+		})
+
+		When("parameters are not present", func() {
+			It("should leave filters nil", func() {
+				req := &DecodeTestRequest{}
+				err := pgquery.DecodeValues(url.Values{}, req)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(req).To(Equal(&DecodeTestRequest{}))
+			})
+		})
+
+		When("parameter is invalid", func() {
+			It("should return error naming the parameter", func() {
+				cases := map[string]string{
+					"age[gte]=abc":            "age[gte]",
+					"created_at[to]=tomorrow": "created_at[to]",
+					"sort=-created_at%20drop": "sort",
+					"limit=-1":                "limit",
+				}
+				for query, param := range cases {
+					values, err := url.ParseQuery(query)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = pgquery.DecodeValues(values, &DecodeTestRequest{})
+					var decodeErr *pgquery.DecodeError
+					if Expect(errors.As(err, &decodeErr)).To(BeTrue(), query) {
+						Expect(decodeErr.Param).To(Equal(param))
+					}
+				}
+			})
+		})
+
+		When("decoding json parameter into nil field", func() {
+			It("should initialize relative datetime range", func() {
+				req := struct {
+					Seen *pgquery.RelativeDateTimeRange
+				}{}
+
+				values, err := url.ParseQuery(`seen={"olderThan":{"day":30}}`)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pgquery.DecodeValues(values, &req)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(req.Seen).ToNot(BeNil())
+				Expect(*req.Seen.Ago.Day).To(Equal(30))
+			})
+
+			It("should return error naming the parameter for group without factory", func() {
+				req := struct {
+					Filters *pgquery.Group
+				}{}
+
+				values, err := url.ParseQuery(`filters={"status":"a"}`)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pgquery.DecodeValues(values, &req)
+				var decodeErr *pgquery.DecodeError
+				if Expect(errors.As(err, &decodeErr)).To(BeTrue()) {
+					Expect(decodeErr.Param).To(Equal("filters"))
+				}
+
+				err = pgquery.DecodeValues(url.Values{}, &req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(req.Filters).To(BeNil())
+			})
+
+			It("should decode group initialized with factory", func() {
+				req := struct {
+					Filters *pgquery.Group
+				}{pgquery.And().Factory(func(field string) (pgquery.Filter, error) {
+					return pgquery.NewMatch(field), nil
+				})}
+
+				values, err := url.ParseQuery(`filters={"status":"a"}`)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pgquery.DecodeValues(values, &req)
+				Expect(err).ToNot(HaveOccurred())
+
+				b, err := json.Marshal(req.Filters)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(b).To(MatchJSON(`{"and":[{"status":"a"}]}`))
+			})
+		})
+
+		When("value is not a struct pointer", func() {
+			It("should return error", func() {
+				err := pgquery.DecodeValues(url.Values{}, DecodeTestRequest{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &DecodeTestItem{})
+
+			values, err := url.ParseQuery("status=a,b&age[gte]=18&created_at[from]=2020-01-01&sort=-created_at,name&q=foo&page=2&limit=20")
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &DecodeTestRequest{}
+			err = pgquery.DecodeValues(values, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err = pgquery.Bind(q, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "decode_test_item"."id", "decode_test_item"."name", "decode_test_item"."status", "decode_test_item"."age", "decode_test_item"."created_at" FROM "decode_test_items" AS "decode_test_item" WHERE ("status" IN ('a','b')) AND (("age" >= 18)) AND (("created_at" >= '2020-01-01T00:00:00Z')) AND ("name" ILIKE '%foo%') ORDER BY "created_at" DESC, "name" ASC LIMIT 20 OFFSET 20`))
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*DecodeTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &DecodeTestItem{
+					Name:      fmt.Sprintf("name-%d", itemCount),
+					Status:    []string{"active", "inactive"}[itemCount%2],
+					Age:       itemCount * 5,
+					CreatedAt: testTime.AddDate(0, 0, itemCount),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with query string", func() {
+			var items []DecodeTestItem
+			q := db.Model(&items)
+
+			values, err := url.ParseQuery("status=active&age[gte]=18&sort=-age&limit=2")
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &DecodeTestRequest{}
+			err = pgquery.DecodeValues(values, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err = pgquery.Bind(q, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(2)) {
+				Expect(items[0].Name).To(Equal("name-10"))
+				Expect(items[1].Name).To(Equal("name-8"))
+			}
+		})
+	})
+})
@@ -0,0 +1,123 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// valuesEncoder implemented by filters encodable to query string values.
+type valuesEncoder interface {
+	encodeValues(param string, values url.Values) error
+}
+
+// EncodeValues encodes the filter fields of the request struct back to query string values, using
+// the same parameter names and conventions as DecodeValues. Nil or unset filters are skipped.
+// url.Values.Encode sorts the parameters by name, which makes the encoded query string stable.
+func EncodeValues(v interface{}) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("[Encode]: expected a struct, got %T", v)
+	}
+
+	values := make(url.Values)
+	if err := encodeStruct(values, rv); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func encodeStruct(values url.Values, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get(bindTag)
+		if tag == "-" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && !isFilterType(fv.Type()) {
+			if fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeStruct(values, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		param := paramName(field, parseTagOptions(tag))
+
+		if fv.Type() == ordersType {
+			if err := encodeOrders(param, values, fv.Interface().([]*Order)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		filters, ok := filterValues(fv)
+		if !ok {
+			continue
+		}
+		for _, f := range filters {
+			if z, ok := f.(zeroer); ok && z.isZero() {
+				continue
+			}
+			if err := encodeFilter(param, values, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func encodeFilter(param string, values url.Values, f Filter) error {
+	if e, ok := f.(valuesEncoder); ok {
+		return e.encodeValues(param, values)
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		values.Set(param, s)
+		return nil
+	}
+	values.Set(param, string(b))
+	return nil
+}
+
+// encodeOrders encodes the orders to the comma separated sort parameter, e.g. "sort=-created_at,name".
+func encodeOrders(param string, values url.Values, orders []*Order) error {
+	list := make([]string, 0, len(orders))
+	for _, o := range orders {
+		if o == nil || o.isZero() {
+			continue
+		}
+		list = append(list, o.format())
+	}
+	if len(list) > 0 {
+		values.Set(param, strings.Join(list, ","))
+	}
+	return nil
+}
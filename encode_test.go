@@ -0,0 +1,99 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncodeValues", func() {
+
+	type EncodeTestRequest struct {
+		Status     *pgquery.Match
+		Deleted    *pgquery.Exists
+		Age        *pgquery.Range
+		CreatedAt  *pgquery.DateTimeRange `pgquery:"layout=2006-01-02"`
+		Sort       []*pgquery.Order
+		Name       *pgquery.KeywordSearch `pgquery:"param=q"`
+		Pagination *pgquery.OffsetPagination
+	}
+
+	Context("encoding values", func() {
+		It("should encode values successfully", func() {
+			t, err := time.Parse("2006-01-02", "2021-01-15")
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &EncodeTestRequest{
+				Status:     pgquery.NewMatch("").Matches("a", "b"),
+				Deleted:    pgquery.NewExists("").ShouldNotExists(),
+				Age:        pgquery.NewRange("").GreaterThanEqual(18).LessThan(65),
+				CreatedAt:  pgquery.NewDateTimeRange("").From(t).ToMarshalLayout("2006-01-02").To(t),
+				Sort:       []*pgquery.Order{pgquery.NewOrderDesc("created_at"), pgquery.NewOrderAsc("name")},
+				Name:       pgquery.NewKeywordSearch("").Keyword("foo"),
+				Pagination: pgquery.NewOffsetPagination().Offset(2, 20),
+			}
+
+			values, err := pgquery.EncodeValues(req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(values.Encode()).To(Equal("age%5Bgte%5D=18&age%5Blt%5D=65&created_at%5Bfrom%5D=2021-01-15T00%3A00%3A00Z&created_at%5Bto%5D=2021-01-15&deleted=false&limit=20&page=2&q=foo&sort=-created_at%2Cname&status=a%2Cb"))
+		})
+
+		When("filters are nil or unset", func() {
+			It("should skip filters", func() {
+				req := &EncodeTestRequest{
+					Status: pgquery.NewMatch(""),
+				}
+
+				values, err := pgquery.EncodeValues(req)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(values).To(BeEmpty())
+			})
+		})
+
+		When("building next and previous pages", func() {
+			It("should encode values successfully", func() {
+				req := &EncodeTestRequest{
+					Pagination: pgquery.NewOffsetPagination().Offset(2, 20),
+				}
+
+				req.Pagination = req.Pagination.Next()
+				values, err := pgquery.EncodeValues(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(values.Encode()).To(Equal("limit=20&page=3"))
+
+				req.Pagination = req.Pagination.Prev().Prev()
+				values, err = pgquery.EncodeValues(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(values.Encode()).To(Equal("limit=20&page=1"))
+
+				Expect(req.Pagination.Prev()).To(BeNil())
+			})
+		})
+	})
+
+	Context("round trip", func() {
+		It("should decode encoded values to the same filters", func() {
+			query := "age%5Bgte%5D=18&created_at%5Bbefore%5D=2021-01-15&created_at%5Bfrom%5D=2020-01-01T10%3A00%3A00Z&limit=20&page=2&q=foo&sort=-created_at%2Cname&status=a%2Cb"
+			values, err := url.ParseQuery(query)
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &EncodeTestRequest{}
+			err = pgquery.DecodeValues(values, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			values, err = pgquery.EncodeValues(req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(values.Encode()).To(Equal(query))
+		})
+	})
+})
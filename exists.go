@@ -7,7 +7,10 @@ package pgquery
 import (
 	"encoding/json"
 	"errors"
+	"net/url"
+	"strconv"
 
+	"github.com/go-pg/pg/v10/orm"
 	"github.com/go-pg/pg/v10/types"
 )
 
@@ -16,7 +19,7 @@ import (
 
 // Exists exists common filter.
 type Exists struct {
-	column string
+	column sqlColumn
 	Value  *bool `json:"value,omitempty"`
 }
 
@@ -48,13 +51,13 @@ func (f *Exists) MarshalJSON() ([]byte, error) {
 // NewExists initializes a new exists filter.
 func NewExists(column string) *Exists {
 	return &Exists{
-		column: column,
+		column: sqlColumn{name: column},
 	}
 }
 
 // Column set the column(s) for the exists filter.
 func (f *Exists) Column(column string) *Exists {
-	f.column = column
+	f.column = sqlColumn{name: column}
 	return f
 }
 
@@ -84,5 +87,40 @@ func (f *Exists) buildValue() string {
 // Appender returns parameters for cond appender.
 func (f *Exists) Appender() (string, interface{}, interface{}) {
 	v := f.buildValue()
-	return "? ?", types.Ident(f.column), types.Safe(v)
+	return "? ?", buildIdent(f.column), types.Safe(v)
+}
+
+// Apply applies the exists filter to the query.
+func (f *Exists) Apply(q *orm.Query) (*orm.Query, error) {
+	return q.Where(f.Appender()), nil
+}
+
+func (f *Exists) isZero() bool {
+	return f.Value == nil
+}
+
+func (f *Exists) bind(opts *tagOptions) error {
+	if err := opts.allow(); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	return nil
+}
+
+func (f *Exists) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	v, ok := paramValue(values, param)
+	if !ok {
+		return false, nil
+	}
+	exists, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, decodeError(param, errors.New("expected a boolean"))
+	}
+	f.Exists(exists)
+	return true, nil
+}
+
+func (f *Exists) encodeValues(param string, values url.Values) error {
+	values.Set(param, strconv.FormatBool(*f.Value))
+	return nil
 }
@@ -81,22 +81,23 @@ var _ = Describe("Exists", func() {
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*ExistsTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			name := ""
-			if itemCount%2 == 0 {
-				name = fmt.Sprintf("name-%d", itemCount)
-			}
-			item := &ExistsTestItem{
-				Name: name,
+			createTempTables((*ExistsTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				name := ""
+				if itemCount%2 == 0 {
+					name = fmt.Sprintf("name-%d", itemCount)
+				}
+				item := &ExistsTestItem{
+					Name: name,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
-			Expect(err).ToNot(HaveOccurred())
-		}
+		})
 
 		It("works with true value", func() {
 			var items []ExistsTestItem
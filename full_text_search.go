@@ -0,0 +1,220 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// TSQueryParser full text search query parser enum type.
+type TSQueryParser int
+
+const (
+	// TSQueryParserPlain plainto_tsquery parser enum, all words are matched.
+	TSQueryParserPlain TSQueryParser = iota
+
+	// TSQueryParserPhrase phraseto_tsquery parser enum, words are matched as a phrase.
+	TSQueryParserPhrase
+
+	// TSQueryParserWebsearch websearch_to_tsquery parser enum, supports "quoted phrase", or and -word.
+	TSQueryParserWebsearch
+)
+
+// String returns the function name for the query parser.
+func (p TSQueryParser) String() string {
+	return [...]string{"plainto_tsquery", "phraseto_tsquery", "websearch_to_tsquery"}[p]
+}
+
+// FullTextSearch full text search common filter. The column should be a tsvector column or
+// expression, use ToTSVector to search a text column.
+type FullTextSearch struct {
+	column     sqlColumn
+	config     string
+	parser     TSQueryParser
+	toTSVector bool
+	rank       string
+	Value      *string `json:"value,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler.
+func (f *FullTextSearch) UnmarshalJSON(b []byte) error {
+	type alias FullTextSearch
+
+	m1 := alias{}
+	var m2 *string
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Value = m1.Value
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Value = m2
+		return nil
+	}
+
+	return errors.New("[FullTextSearch]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *FullTextSearch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Value)
+}
+
+// NewFullTextSearch initializes a new full text search filter.
+func NewFullTextSearch(column string) *FullTextSearch {
+	return &FullTextSearch{
+		column: sqlColumn{name: column},
+	}
+}
+
+// Column set the column for the full text search filter.
+func (f *FullTextSearch) Column(column string) *FullTextSearch {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Config set the text search configuration (regconfig) for the full text search filter, e.g.
+// "english". The default_text_search_config is used when not set.
+func (f *FullTextSearch) Config(config string) *FullTextSearch {
+	f.config = config
+	return f
+}
+
+// Parser set the query parser for the full text search filter.
+func (f *FullTextSearch) Parser(parser TSQueryParser) *FullTextSearch {
+	f.parser = parser
+	return f
+}
+
+// Phrase set phraseto_tsquery parser for the full text search filter.
+func (f *FullTextSearch) Phrase() *FullTextSearch {
+	return f.Parser(TSQueryParserPhrase)
+}
+
+// Websearch set websearch_to_tsquery parser for the full text search filter.
+func (f *FullTextSearch) Websearch() *FullTextSearch {
+	return f.Parser(TSQueryParserWebsearch)
+}
+
+// ToTSVector set the column as text, converted with to_tsvector for the full text search filter.
+func (f *FullTextSearch) ToTSVector() *FullTextSearch {
+	f.toTSVector = true
+	return f
+}
+
+// Rank set order by ts_rank descending for the full text search filter.
+func (f *FullTextSearch) Rank() *FullTextSearch {
+	f.rank = "ts_rank"
+	return f
+}
+
+// RankCD set order by ts_rank_cd (cover density) descending for the full text search filter.
+func (f *FullTextSearch) RankCD() *FullTextSearch {
+	f.rank = "ts_rank_cd"
+	return f
+}
+
+// Keyword set value.
+func (f *FullTextSearch) Keyword(keyword string) *FullTextSearch {
+	f.Value = &keyword
+	return f
+}
+
+func (f *FullTextSearch) buildValue() string {
+	if f.Value != nil {
+		return *f.Value
+	}
+	return ""
+}
+
+func (f *FullTextSearch) buildVector() interface{} {
+	column := buildIdent(f.column)
+	if !f.toTSVector {
+		return column
+	}
+	if f.config != "" {
+		return orm.SafeQuery("to_tsvector(?::regconfig, ?)", f.config, column)
+	}
+	return orm.SafeQuery("to_tsvector(?)", column)
+}
+
+func (f *FullTextSearch) buildQuery() interface{} {
+	if f.config != "" {
+		return orm.SafeQuery(f.parser.String()+"(?::regconfig, ?)", f.config, f.buildValue())
+	}
+	return orm.SafeQuery(f.parser.String()+"(?)", f.buildValue())
+}
+
+// Appender returns parameters for cond appender.
+func (f *FullTextSearch) Appender() (string, interface{}, interface{}) {
+	return "? @@ ?", f.buildVector(), f.buildQuery()
+}
+
+// RankAppender returns parameters for order appender, ranks rows by descending relevance.
+func (f *FullTextSearch) RankAppender() (string, interface{}) {
+	rank := f.rank
+	if rank == "" {
+		rank = "ts_rank"
+	}
+	return "? DESC", orm.SafeQuery(rank+"(?, ?)", f.buildVector(), f.buildQuery())
+}
+
+// Apply applies the full text search filter to the query.
+func (f *FullTextSearch) Apply(q *orm.Query) (*orm.Query, error) {
+	q.Where(f.Appender())
+	if f.rank != "" {
+		q.OrderExpr(f.RankAppender())
+	}
+	return q, nil
+}
+
+func (f *FullTextSearch) isZero() bool {
+	return f.Value == nil
+}
+
+func (f *FullTextSearch) bind(opts *tagOptions) error {
+	if err := opts.allow("config", "phrase", "websearch", "toTSVector", "rank", "rankCD"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("config") {
+		f.Config(opts.get("config"))
+	}
+	if opts.has("phrase") {
+		f.Phrase()
+	}
+	if opts.has("websearch") {
+		f.Websearch()
+	}
+	if opts.has("toTSVector") {
+		f.ToTSVector()
+	}
+	if opts.has("rank") {
+		f.Rank()
+	}
+	if opts.has("rankCD") {
+		f.RankCD()
+	}
+	return nil
+}
+
+func (f *FullTextSearch) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	v, ok := paramValue(values, param)
+	if !ok {
+		return false, nil
+	}
+	f.Keyword(v)
+	return true, nil
+}
+
+func (f *FullTextSearch) encodeValues(param string, values url.Values) error {
+	values.Set(param, *f.Value)
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FullTextSearch", func() {
+
+	type FullTextSearchTestItem struct {
+		Id   int64
+		Body string
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			f := pgquery.NewFullTextSearch("").Keyword("keyword")
+
+			b, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`"keyword"`))
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		When("using object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewFullTextSearch("")
+
+				err := json.Unmarshal([]byte(`{"value":"keyword"}`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewFullTextSearch("").Keyword("keyword")))
+			})
+		})
+
+		When("using non-object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewFullTextSearch("")
+
+				err := json.Unmarshal([]byte(`"keyword"`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewFullTextSearch("").Keyword("keyword")))
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &FullTextSearchTestItem{})
+
+			q.Where(pgquery.NewFullTextSearch("search").Keyword("quick fox").Appender())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "full_text_search_test_item"."id", "full_text_search_test_item"."body" FROM "full_text_search_test_items" AS "full_text_search_test_item" WHERE ("search" @@ plainto_tsquery('quick fox'))`))
+		})
+
+		When("using config and websearch parser", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &FullTextSearchTestItem{})
+
+				q.Where(pgquery.NewFullTextSearch("body").Config("english").Websearch().ToTSVector().Keyword(`"quick fox" -dog`).Appender())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "full_text_search_test_item"."id", "full_text_search_test_item"."body" FROM "full_text_search_test_items" AS "full_text_search_test_item" WHERE (to_tsvector('english'::regconfig, "body") @@ websearch_to_tsquery('english'::regconfig, '"quick fox" -dog'))`))
+			})
+		})
+
+		When("using rank ordering", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &FullTextSearchTestItem{})
+
+				q, err := pgquery.NewFullTextSearch("search").Phrase().RankCD().Keyword("quick fox").Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "full_text_search_test_item"."id", "full_text_search_test_item"."body" FROM "full_text_search_test_items" AS "full_text_search_test_item" WHERE ("search" @@ phraseto_tsquery('quick fox')) ORDER BY ts_rank_cd("search", phraseto_tsquery('quick fox')) DESC`))
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*FullTextSearchTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				body := fmt.Sprintf("item number %d", itemCount)
+				switch itemCount {
+				case 3:
+					body = "the quick brown fox jumps over the lazy dog"
+				case 7:
+					body = "a quick fox, a quick fox, a quick fox"
+				}
+				item := &FullTextSearchTestItem{
+					Body: body,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with plain search", func() {
+			var items []FullTextSearchTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewFullTextSearch("body").Config("english").ToTSVector().Keyword("quick foxes").Apply(q.Order("id"))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(2)) {
+				Expect(items[0].Id).To(Equal(int64(3)))
+				Expect(items[1].Id).To(Equal(int64(7)))
+			}
+		})
+
+		It("works with phrase search", func() {
+			var items []FullTextSearchTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewFullTextSearch("body").Config("english").ToTSVector().Phrase().Keyword("quick fox").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(1)) {
+				Expect(items[0].Id).To(Equal(int64(7)))
+			}
+		})
+
+		It("works with websearch", func() {
+			var items []FullTextSearchTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewFullTextSearch("body").Config("english").ToTSVector().Websearch().Keyword("quick -dog or number").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(9))
+		})
+
+		It("works with rank", func() {
+			var items []FullTextSearchTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewFullTextSearch("body").Config("english").ToTSVector().Rank().Keyword("quick fox").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(2)) {
+				Expect(items[0].Id).To(Equal(int64(7)))
+				Expect(items[1].Id).To(Equal(int64(3)))
+			}
+		})
+	})
+})
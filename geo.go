@@ -0,0 +1,477 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// DefaultSRID default spatial reference system identifier, WGS 84.
+const DefaultSRID = 4326
+
+// GeoPoint longitude and latitude point.
+type GeoPoint struct {
+	Lng float64 `json:"lng"`
+	Lat float64 `json:"lat"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler, accepts {"lng":...,"lat":...} or [lng,lat].
+func (p *GeoPoint) UnmarshalJSON(b []byte) error {
+	type alias GeoPoint
+
+	m1 := alias{}
+	var m2 []float64
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		if len(m2) != 2 {
+			return errors.New("[GeoPoint]: expected [lng,lat]")
+		}
+		p.Lng, p.Lat = m2[0], m2[1]
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		*p = GeoPoint(m1)
+		return nil
+	}
+
+	return errors.New("[GeoPoint]: unsupported format when unmarshalling json")
+}
+
+func (p GeoPoint) validate() error {
+	for _, v := range []float64{p.Lng, p.Lat} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("invalid coordinate %v", v)
+		}
+	}
+	return nil
+}
+
+// GeoRadius radius around a point, in meters for geography and SRID units for geometry.
+type GeoRadius struct {
+	Lng    float64 `json:"lng"`
+	Lat    float64 `json:"lat"`
+	Radius float64 `json:"radius"`
+}
+
+// GeoBBox bounding box.
+type GeoBBox struct {
+	MinLng float64 `json:"minLng"`
+	MinLat float64 `json:"minLat"`
+	MaxLng float64 `json:"maxLng"`
+	MaxLat float64 `json:"maxLat"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler, accepts the object or [minLng,minLat,maxLng,maxLat].
+func (b *GeoBBox) UnmarshalJSON(data []byte) error {
+	type alias GeoBBox
+
+	m1 := alias{}
+	var m2 []float64
+
+	if err := json.Unmarshal(data, &m2); err == nil {
+		if len(m2) != 4 {
+			return errors.New("[GeoBBox]: expected [minLng,minLat,maxLng,maxLat]")
+		}
+		b.MinLng, b.MinLat, b.MaxLng, b.MaxLat = m2[0], m2[1], m2[2], m2[3]
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &m1); err == nil {
+		*b = GeoBBox(m1)
+		return nil
+	}
+
+	return errors.New("[GeoBBox]: unsupported format when unmarshalling json")
+}
+
+// buildWKT returns the well-known text of the polygon, the ring is closed when needed.
+func buildWKT(points []GeoPoint) (string, error) {
+	if len(points) < 3 {
+		return "", errors.New("polygon requires at least 3 points")
+	}
+	if points[0] != points[len(points)-1] {
+		points = append(points[:len(points):len(points)], points[0])
+	}
+	coords := make([]string, 0, len(points))
+	for _, p := range points {
+		if err := p.validate(); err != nil {
+			return "", err
+		}
+		coords = append(coords, strconv.FormatFloat(p.Lng, 'f', -1, 64)+" "+strconv.FormatFloat(p.Lat, 'f', -1, 64))
+	}
+	return "POLYGON((" + strings.Join(coords, ", ") + "))", nil
+}
+
+// geoShape builds PostGIS expressions for the column type and SRID.
+type geoShape struct {
+	geography bool
+	srid      int
+}
+
+func (s geoShape) cast(q *orm.SafeQueryAppender) interface{} {
+	if s.geography {
+		return orm.SafeQuery("?::geography", q)
+	}
+	return q
+}
+
+func (s geoShape) point(lng, lat float64) interface{} {
+	return s.cast(orm.SafeQuery("ST_SetSRID(ST_MakePoint(?, ?), ?)", lng, lat, s.srid))
+}
+
+func (s geoShape) envelope(b *GeoBBox) interface{} {
+	return s.cast(orm.SafeQuery("ST_MakeEnvelope(?, ?, ?, ?, ?)", b.MinLng, b.MinLat, b.MaxLng, b.MaxLat, s.srid))
+}
+
+func (s geoShape) polygon(wkt string) interface{} {
+	return s.cast(orm.SafeQuery("ST_GeomFromText(?, ?)", wkt, s.srid))
+}
+
+// GeoFilter PostGIS geospatial common filter, the column is a geometry or geography column.
+type GeoFilter struct {
+	column    sqlColumn
+	geography bool
+	srid      int
+	Radius    *GeoRadius `json:"radius,omitempty"`
+	BBox      *GeoBBox   `json:"bbox,omitempty"`
+	Polygon   []GeoPoint `json:"polygon,omitempty"`
+}
+
+// NewGeoFilter initializes a new geo filter.
+func NewGeoFilter(column string) *GeoFilter {
+	return &GeoFilter{
+		column: sqlColumn{name: column},
+		srid:   DefaultSRID,
+	}
+}
+
+// Column set the column for the geo filter.
+func (f *GeoFilter) Column(column string) *GeoFilter {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Geography set the column type to geography for the geo filter, distances are in meters.
+func (f *GeoFilter) Geography() *GeoFilter {
+	f.geography = true
+	return f
+}
+
+// SRID set the spatial reference system identifier of the coordinates for the geo filter.
+func (f *GeoFilter) SRID(srid int) *GeoFilter {
+	f.srid = srid
+	return f
+}
+
+// WithinRadius set value for within radius (ST_DWithin).
+func (f *GeoFilter) WithinRadius(lng, lat, radius float64) *GeoFilter {
+	f.Radius = &GeoRadius{Lng: lng, Lat: lat, Radius: radius}
+	return f
+}
+
+// IntersectsBBox set value for bounding box intersection (&&).
+func (f *GeoFilter) IntersectsBBox(minLng, minLat, maxLng, maxLat float64) *GeoFilter {
+	f.BBox = &GeoBBox{MinLng: minLng, MinLat: minLat, MaxLng: maxLng, MaxLat: maxLat}
+	return f
+}
+
+// WithinPolygon set value for within polygon (ST_Covers).
+func (f *GeoFilter) WithinPolygon(points ...GeoPoint) *GeoFilter {
+	f.Polygon = points
+	return f
+}
+
+func (f *GeoFilter) shape() geoShape {
+	srid := f.srid
+	if srid == 0 {
+		srid = DefaultSRID
+	}
+	return geoShape{geography: f.geography, srid: srid}
+}
+
+// Appender returns parameters for cond group appender.
+func (f *GeoFilter) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		shape := f.shape()
+		column := buildIdent(f.column)
+		if r := f.Radius; r != nil {
+			if err := (GeoPoint{Lng: r.Lng, Lat: r.Lat}).validate(); err != nil {
+				return q, fmt.Errorf("[GeoFilter]: %v", err)
+			}
+			if r.Radius < 0 || math.IsNaN(r.Radius) || math.IsInf(r.Radius, 0) {
+				return q, fmt.Errorf("[GeoFilter]: invalid radius %v", r.Radius)
+			}
+			q.Where("ST_DWithin(?, ?, ?)", column, shape.point(r.Lng, r.Lat), r.Radius)
+		}
+		if b := f.BBox; b != nil {
+			for _, p := range []GeoPoint{{Lng: b.MinLng, Lat: b.MinLat}, {Lng: b.MaxLng, Lat: b.MaxLat}} {
+				if err := p.validate(); err != nil {
+					return q, fmt.Errorf("[GeoFilter]: %v", err)
+				}
+			}
+			q.Where("? && ?", column, shape.envelope(b))
+		}
+		if f.Polygon != nil {
+			wkt, err := buildWKT(f.Polygon)
+			if err != nil {
+				return q, fmt.Errorf("[GeoFilter]: %v", err)
+			}
+			q.Where("ST_Covers(?, ?)", shape.polygon(wkt), column)
+		}
+		return q, nil
+	}
+}
+
+// Apply applies the geo filter to the query.
+func (f *GeoFilter) Apply(q *orm.Query) (*orm.Query, error) {
+	var err error
+	q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q, err = f.Appender()(q)
+		return q, err
+	})
+	return q, err
+}
+
+func (f *GeoFilter) isZero() bool {
+	return f.Radius == nil && f.BBox == nil && f.Polygon == nil
+}
+
+func (f *GeoFilter) bind(opts *tagOptions) error {
+	if err := opts.allow("geography", "srid"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("geography") {
+		f.Geography()
+	}
+	if opts.has("srid") {
+		srid, err := strconv.Atoi(opts.get("srid"))
+		if err != nil {
+			return fmt.Errorf("invalid srid %q", opts.get("srid"))
+		}
+		f.SRID(srid)
+	}
+	return nil
+}
+
+// paramFloats returns the comma separated numbers of the parameter.
+func paramFloats(values url.Values, key string) ([]float64, error) {
+	list := paramList(values, key)
+	if len(list) == 0 {
+		return nil, nil
+	}
+	floats := make([]float64, 0, len(list))
+	for _, v := range list {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, decodeError(key, errors.New("expected comma separated numbers"))
+		}
+		floats = append(floats, n)
+	}
+	return floats, nil
+}
+
+func formatFloats(floats ...float64) string {
+	list := make([]string, 0, len(floats))
+	for _, n := range floats {
+		list = append(list, strconv.FormatFloat(n, 'f', -1, 64))
+	}
+	return strings.Join(list, ",")
+}
+
+// decodeValues decodes "param[radius]=lng,lat,radius", "param[bbox]=minLng,minLat,maxLng,maxLat"
+// and "param[polygon]=lng,lat,lng,lat,...".
+func (f *GeoFilter) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	found := false
+	key := param + "[radius]"
+	if v, err := paramFloats(values, key); err != nil {
+		return false, err
+	} else if v != nil {
+		if len(v) != 3 {
+			return false, decodeError(key, errors.New("expected lng,lat,radius"))
+		}
+		f.WithinRadius(v[0], v[1], v[2])
+		found = true
+	}
+	key = param + "[bbox]"
+	if v, err := paramFloats(values, key); err != nil {
+		return false, err
+	} else if v != nil {
+		if len(v) != 4 {
+			return false, decodeError(key, errors.New("expected minLng,minLat,maxLng,maxLat"))
+		}
+		f.IntersectsBBox(v[0], v[1], v[2], v[3])
+		found = true
+	}
+	key = param + "[polygon]"
+	if v, err := paramFloats(values, key); err != nil {
+		return false, err
+	} else if v != nil {
+		if len(v)%2 != 0 {
+			return false, decodeError(key, errors.New("expected lng,lat pairs"))
+		}
+		points := make([]GeoPoint, 0, len(v)/2)
+		for i := 0; i < len(v); i += 2 {
+			points = append(points, GeoPoint{Lng: v[i], Lat: v[i+1]})
+		}
+		f.WithinPolygon(points...)
+		found = true
+	}
+	return found, nil
+}
+
+func (f *GeoFilter) encodeValues(param string, values url.Values) error {
+	if r := f.Radius; r != nil {
+		values.Set(param+"[radius]", formatFloats(r.Lng, r.Lat, r.Radius))
+	}
+	if b := f.BBox; b != nil {
+		values.Set(param+"[bbox]", formatFloats(b.MinLng, b.MinLat, b.MaxLng, b.MaxLat))
+	}
+	if f.Polygon != nil {
+		floats := make([]float64, 0, len(f.Polygon)*2)
+		for _, p := range f.Polygon {
+			floats = append(floats, p.Lng, p.Lat)
+		}
+		values.Set(param+"[polygon]", formatFloats(floats...))
+	}
+	return nil
+}
+
+// GeoDistance PostGIS distance common sorter, orders by KNN distance (<->) to the point, nearest first.
+type GeoDistance struct {
+	column    sqlColumn
+	geography bool
+	srid      int
+	From      *GeoPoint `json:"from,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler, accepts the point or {"from":point}.
+func (s *GeoDistance) UnmarshalJSON(b []byte) error {
+	m1 := struct {
+		From *GeoPoint `json:"from"`
+	}{}
+	var m2 GeoPoint
+
+	if err := json.Unmarshal(b, &m1); err == nil && m1.From != nil {
+		s.From = m1.From
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		s.From = &m2
+		return nil
+	}
+
+	return errors.New("[GeoDistance]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (s *GeoDistance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.From)
+}
+
+// NewGeoDistance initializes a new geo distance sorter.
+func NewGeoDistance(column string) *GeoDistance {
+	return &GeoDistance{
+		column: sqlColumn{name: column},
+		srid:   DefaultSRID,
+	}
+}
+
+// Column set the column for the geo distance sorter.
+func (s *GeoDistance) Column(column string) *GeoDistance {
+	s.column = sqlColumn{name: column}
+	return s
+}
+
+// Geography set the column type to geography for the geo distance sorter.
+func (s *GeoDistance) Geography() *GeoDistance {
+	s.geography = true
+	return s
+}
+
+// SRID set the spatial reference system identifier of the point for the geo distance sorter.
+func (s *GeoDistance) SRID(srid int) *GeoDistance {
+	s.srid = srid
+	return s
+}
+
+// Nearest set the point to order the distance from.
+func (s *GeoDistance) Nearest(lng, lat float64) *GeoDistance {
+	s.From = &GeoPoint{Lng: lng, Lat: lat}
+	return s
+}
+
+// Appender returns parameters for order appender.
+func (s *GeoDistance) Appender() (string, interface{}, interface{}) {
+	srid := s.srid
+	if srid == 0 {
+		srid = DefaultSRID
+	}
+	var from GeoPoint
+	if s.From != nil {
+		from = *s.From
+	}
+	shape := geoShape{geography: s.geography, srid: srid}
+	return "? <-> ?", buildIdent(s.column), shape.point(from.Lng, from.Lat)
+}
+
+// Apply applies the geo distance sorter to the query.
+func (s *GeoDistance) Apply(q *orm.Query) (*orm.Query, error) {
+	if s.From != nil {
+		if err := s.From.validate(); err != nil {
+			return q, fmt.Errorf("[GeoDistance]: %v", err)
+		}
+	}
+	return q.OrderExpr(s.Appender()), nil
+}
+
+func (s *GeoDistance) isZero() bool {
+	return s.From == nil
+}
+
+func (s *GeoDistance) bind(opts *tagOptions) error {
+	if err := opts.allow("geography", "srid"); err != nil {
+		return err
+	}
+	s.column = opts.columnFor(s.column)
+	if opts.has("geography") {
+		s.Geography()
+	}
+	if opts.has("srid") {
+		srid, err := strconv.Atoi(opts.get("srid"))
+		if err != nil {
+			return fmt.Errorf("invalid srid %q", opts.get("srid"))
+		}
+		s.SRID(srid)
+	}
+	return nil
+}
+
+func (s *GeoDistance) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	v, err := paramFloats(values, param)
+	if err != nil || v == nil {
+		return false, err
+	}
+	if len(v) != 2 {
+		return false, decodeError(param, errors.New("expected lng,lat"))
+	}
+	s.Nearest(v[0], v[1])
+	return true, nil
+}
+
+func (s *GeoDistance) encodeValues(param string, values url.Values) error {
+	values.Set(param, formatFloats(s.From.Lng, s.From.Lat))
+	return nil
+}
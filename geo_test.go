@@ -0,0 +1,273 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GeoFilter", func() {
+
+	type GeoFilterTestItem struct {
+		Id       int64
+		Location string `pg:"type:geography(Point,4326)"`
+		Shape    string `pg:"type:geometry(Point,4326)"`
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			f := pgquery.NewGeoFilter("").
+				WithinRadius(101.69, 3.14, 500).
+				IntersectsBBox(101, 3, 102, 4)
+
+			b, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`{"radius":{"lng":101.69,"lat":3.14,"radius":500},"bbox":{"minLng":101,"minLat":3,"maxLng":102,"maxLat":4}}`))
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		It("should unmarshal json successfully", func() {
+			f := pgquery.NewGeoFilter("")
+
+			err := json.Unmarshal([]byte(`{"radius":{"lng":101.69,"lat":3.14,"radius":500},"bbox":[101,3,102,4],"polygon":[[0,0],{"lng":1,"lat":0},[1,1]]}`), f)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(f).To(Equal(pgquery.NewGeoFilter("").
+				WithinRadius(101.69, 3.14, 500).
+				IntersectsBBox(101, 3, 102, 4).
+				WithinPolygon(pgquery.GeoPoint{Lng: 0, Lat: 0}, pgquery.GeoPoint{Lng: 1, Lat: 0}, pgquery.GeoPoint{Lng: 1, Lat: 1})))
+		})
+
+		When("using malformed coordinates", func() {
+			It("should return error", func() {
+				for _, v := range []string{`{"bbox":[1,2,3]}`, `{"polygon":[[1]]}`, `{"polygon":["a"]}`} {
+					err := json.Unmarshal([]byte(v), pgquery.NewGeoFilter(""))
+					Expect(err).To(HaveOccurred(), v)
+				}
+			})
+		})
+	})
+
+	Context("decoding query string", func() {
+		It("should decode and encode query string values", func() {
+			req := struct {
+				Location *pgquery.GeoFilter   `pgquery:"geography"`
+				Near     *pgquery.GeoDistance `pgquery:"location,geography"`
+			}{}
+
+			values, err := url.ParseQuery("location[radius]=101.69,3.14,500&location[polygon]=0,0,1,0,1,1&near=101.69,3.14")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(req.Location.Radius).To(Equal(&pgquery.GeoRadius{Lng: 101.69, Lat: 3.14, Radius: 500}))
+			Expect(req.Near.From).To(Equal(&pgquery.GeoPoint{Lng: 101.69, Lat: 3.14}))
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded).To(Equal(values))
+		})
+
+		When("using wrong number of coordinates", func() {
+			It("should return error", func() {
+				req := struct {
+					Location *pgquery.GeoFilter
+				}{}
+
+				for _, v := range []string{"location[radius]=1,2", "location[bbox]=1,2,3", "location[polygon]=1,2,3", "location[radius]=a,b,c"} {
+					values, err := url.ParseQuery(v)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = pgquery.DecodeValues(values, &req)
+					Expect(err).To(HaveOccurred(), v)
+				}
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &GeoFilterTestItem{})
+
+			q, err := pgquery.NewGeoFilter("shape").
+				WithinRadius(101.69, 3.14, 0.5).
+				IntersectsBBox(101, 3, 102, 4).
+				WithinPolygon(pgquery.GeoPoint{Lng: 0, Lat: 0}, pgquery.GeoPoint{Lng: 1, Lat: 0}, pgquery.GeoPoint{Lng: 1, Lat: 1}).
+				Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "geo_filter_test_item"."id", "geo_filter_test_item"."location", "geo_filter_test_item"."shape" FROM "geo_filter_test_items" AS "geo_filter_test_item" WHERE ((ST_DWithin("shape", ST_SetSRID(ST_MakePoint(101.69, 3.14), 4326), 0.5)) AND ("shape" && ST_MakeEnvelope(101, 3, 102, 4, 4326)) AND (ST_Covers(ST_GeomFromText('POLYGON((0 0, 1 0, 1 1, 0 0))', 4326), "shape")))`))
+		})
+
+		It("should generate correct SQL string for geography", func() {
+			q := orm.NewQuery(nil, &GeoFilterTestItem{})
+
+			q, err := pgquery.NewGeoFilter("location").Geography().SRID(4269).
+				WithinRadius(101.69, 3.14, 500).
+				IntersectsBBox(101, 3, 102, 4).
+				Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "geo_filter_test_item"."id", "geo_filter_test_item"."location", "geo_filter_test_item"."shape" FROM "geo_filter_test_items" AS "geo_filter_test_item" WHERE ((ST_DWithin("location", ST_SetSRID(ST_MakePoint(101.69, 3.14), 4269)::geography, 500)) AND ("location" && ST_MakeEnvelope(101, 3, 102, 4, 4269)::geography))`))
+		})
+
+		When("using invalid values", func() {
+			It("should return error", func() {
+				for _, f := range []*pgquery.GeoFilter{
+					pgquery.NewGeoFilter("shape").WithinRadius(0, 0, -1),
+					pgquery.NewGeoFilter("shape").WithinRadius(math.NaN(), 0, 1),
+					pgquery.NewGeoFilter("shape").IntersectsBBox(0, 0, math.Inf(1), 1),
+					pgquery.NewGeoFilter("shape").WithinPolygon(pgquery.GeoPoint{Lng: 0, Lat: 0}, pgquery.GeoPoint{Lng: 1, Lat: 1}),
+				} {
+					q := orm.NewQuery(nil, &GeoFilterTestItem{})
+
+					_, err := f.Apply(q)
+					Expect(err).To(HaveOccurred())
+				}
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireExtension("postgis")
+
+			createTempTables((*GeoFilterTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				point := fmt.Sprintf("SRID=4326;POINT(%v 0)", float64(itemCount)/100)
+				item := &GeoFilterTestItem{
+					Location: point,
+					Shape:    point,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with radius on geography", func() {
+			var items []GeoFilterTestItem
+			q := db.Model(&items)
+
+			// 0.01 degree of longitude at the equator is roughly 1113 meters.
+			q, err := pgquery.NewGeoFilter("location").Geography().WithinRadius(0.05, 0, 1500).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+
+		It("works with bounding box", func() {
+			var items []GeoFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewGeoFilter("shape").IntersectsBBox(0.015, -1, 0.045, 1).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+
+		It("works with polygon", func() {
+			var items []GeoFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewGeoFilter("location").Geography().
+				WithinPolygon(pgquery.GeoPoint{Lng: 0.075, Lat: -1}, pgquery.GeoPoint{Lng: 0.2, Lat: -1}, pgquery.GeoPoint{Lng: 0.2, Lat: 1}, pgquery.GeoPoint{Lng: 0.075, Lat: 1}).
+				Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+
+		It("works with distance sorter", func() {
+			var items []GeoFilterTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewGeoDistance("shape").Nearest(0.072, 0).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Limit(3).Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(3)) {
+				Expect(items[0].Id).To(Equal(int64(7)))
+				Expect(items[1].Id).To(Equal(int64(8)))
+				Expect(items[2].Id).To(Equal(int64(6)))
+			}
+		})
+	})
+})
+
+var _ = Describe("GeoDistance", func() {
+
+	type GeoDistanceTestItem struct {
+		Id       int64
+		Location string `pg:"type:geography(Point,4326)"`
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			s := pgquery.NewGeoDistance("").Nearest(101.69, 3.14)
+
+			b, err := json.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`{"lng":101.69,"lat":3.14}`))
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		It("should unmarshal json successfully", func() {
+			for _, v := range []string{`{"lng":101.69,"lat":3.14}`, `[101.69,3.14]`, `{"from":[101.69,3.14]}`} {
+				s := pgquery.NewGeoDistance("")
+
+				err := json.Unmarshal([]byte(v), s)
+				Expect(err).ToNot(HaveOccurred(), v)
+
+				Expect(s).To(Equal(pgquery.NewGeoDistance("").Nearest(101.69, 3.14)), v)
+			}
+		})
+
+		When("using malformed point", func() {
+			It("should return error", func() {
+				err := json.Unmarshal([]byte(`"101.69,3.14"`), pgquery.NewGeoDistance(""))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &GeoDistanceTestItem{})
+
+			q, err := pgquery.NewGeoDistance("location").Geography().Nearest(101.69, 3.14).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "geo_distance_test_item"."id", "geo_distance_test_item"."location" FROM "geo_distance_test_items" AS "geo_distance_test_item" ORDER BY "location" <-> ST_SetSRID(ST_MakePoint(101.69, 3.14), 4326)::geography`))
+		})
+	})
+})
@@ -0,0 +1,273 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// GroupOperator group boolean operator enum type.
+type GroupOperator int
+
+const (
+	// GroupOperatorAnd group operator and enum.
+	GroupOperatorAnd GroupOperator = iota
+
+	// GroupOperatorOr group operator or enum.
+	GroupOperatorOr
+
+	// GroupOperatorNot group operator not enum.
+	GroupOperatorNot
+)
+
+// String returns the string presentation for the group operator.
+func (o GroupOperator) String() string {
+	return [...]string{"and", "or", "not"}[o]
+}
+
+func parseGroupOperator(s string) (GroupOperator, bool) {
+	for _, o := range []GroupOperator{GroupOperatorAnd, GroupOperatorOr, GroupOperatorNot} {
+		if strings.ToLower(s) == o.String() {
+			return o, true
+		}
+	}
+	return 0, false
+}
+
+// FilterFactory initializes a new filter for the field name when unmarshalling json.
+type FilterFactory func(field string) (Filter, error)
+
+// groupItem filter of the group with the field name used for marshalling json.
+type groupItem struct {
+	field  string
+	filter Filter
+}
+
+// Group boolean filter group, combines nested filter(s) with AND, OR or NOT.
+type Group struct {
+	operator GroupOperator
+	factory  FilterFactory
+	items    []groupItem
+}
+
+// MarshalJSON custom JSON marshaler.
+func (g *Group) MarshalJSON() ([]byte, error) {
+	items := make([]interface{}, 0, len(g.items))
+	for _, item := range g.items {
+		if isNil(item.filter) {
+			continue
+		}
+		if sub, ok := item.filter.(*Group); ok {
+			items = append(items, sub)
+			continue
+		}
+		if item.field == "" {
+			return nil, errors.New("[Group]: field is not specified for marshal json")
+		}
+		items = append(items, map[string]Filter{item.field: item.filter})
+	}
+	return json.Marshal(map[string]interface{}{g.operator.String(): items})
+}
+
+// UnmarshalJSON custom JSON unmarshaler.
+func (g *Group) UnmarshalJSON(b []byte) error {
+	m1 := make(map[string]json.RawMessage)
+
+	if g.factory == nil {
+		return errors.New("[Group]: factory is not specified for unmarshal json")
+	}
+
+	if err := json.Unmarshal(b, &m1); err != nil {
+		return errors.New("[Group]: unsupported format when unmarshalling json")
+	}
+
+	if len(m1) == 1 {
+		for k, v := range m1 {
+			if operator, ok := parseGroupOperator(k); ok {
+				g.operator = operator
+				return g.unmarshalItems(v)
+			}
+		}
+	}
+
+	g.operator = GroupOperatorAnd
+	return g.unmarshalItem(m1)
+}
+
+func (g *Group) unmarshalItems(b []byte) error {
+	var m1 []map[string]json.RawMessage
+	m2 := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		for _, item := range m1 {
+			if len(item) == 1 {
+				if err := g.unmarshalItem(item); err != nil {
+					return err
+				}
+				continue
+			}
+			sub := And().Factory(g.factory)
+			if err := sub.unmarshalItem(item); err != nil {
+				return err
+			}
+			g.Add(sub)
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		return g.unmarshalItem(m2)
+	}
+
+	return errors.New("[Group]: unsupported format when unmarshalling json")
+}
+
+func (g *Group) unmarshalItem(item map[string]json.RawMessage) error {
+	keys := make([]string, 0, len(item))
+	for k := range item {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if operator, ok := parseGroupOperator(k); ok {
+			sub := NewGroup(operator).Factory(g.factory)
+			if err := sub.unmarshalItems(item[k]); err != nil {
+				return err
+			}
+			g.Add(sub)
+			continue
+		}
+		f, err := g.factory(k)
+		if err != nil {
+			return err
+		}
+		if isNil(f) {
+			return fmt.Errorf("[Group]: unknown field %q when unmarshalling json", k)
+		}
+		if err := json.Unmarshal(item[k], f); err != nil {
+			return err
+		}
+		g.Field(k, f)
+	}
+	return nil
+}
+
+// NewGroup initializes a new filter group.
+func NewGroup(operator GroupOperator, filters ...Filter) *Group {
+	g := &Group{
+		operator: operator,
+	}
+	return g.Add(filters...)
+}
+
+// And initializes a new filter group, matches when all filter(s) match.
+func And(filters ...Filter) *Group {
+	return NewGroup(GroupOperatorAnd, filters...)
+}
+
+// Or initializes a new filter group, matches when any filter(s) match.
+func Or(filters ...Filter) *Group {
+	return NewGroup(GroupOperatorOr, filters...)
+}
+
+// Not initializes a new filter group, matches when not all filter(s) match.
+func Not(filters ...Filter) *Group {
+	return NewGroup(GroupOperatorNot, filters...)
+}
+
+// Factory sets the filter factory used to initialize fields when unmarshalling json.
+func (g *Group) Factory(factory FilterFactory) *Group {
+	g.factory = factory
+	return g
+}
+
+// Add adds filter(s) to the group.
+func (g *Group) Add(filters ...Filter) *Group {
+	for _, f := range filters {
+		g.Field("", f)
+	}
+	return g
+}
+
+// Field adds a filter to the group under the field name used for marshalling json.
+func (g *Group) Field(field string, filter Filter) *Group {
+	g.items = append(g.items, groupItem{field: field, filter: filter})
+	return g
+}
+
+// Appender returns parameters for cond group appender.
+func (g *Group) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		for _, item := range g.items {
+			if isNil(item.filter) {
+				continue
+			}
+			f := item.filter
+			var err error
+			apply := func(q *orm.Query) (*orm.Query, error) {
+				q, err = f.Apply(q)
+				return q, err
+			}
+			if g.operator == GroupOperatorOr {
+				q = q.WhereOrGroup(apply)
+			} else {
+				q = q.WhereGroup(apply)
+			}
+			if err != nil {
+				return q, err
+			}
+		}
+		return q, nil
+	}
+}
+
+// Apply applies the filter group to the query.
+func (g *Group) Apply(q *orm.Query) (*orm.Query, error) {
+	var err error
+	if g.operator == GroupOperatorNot {
+		if g.isZero() {
+			return q, nil
+		}
+		// go-pg omits the separator of the first condition in a group, anchor NOT with TRUE.
+		q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.Where("TRUE").WhereNotGroup(func(q *orm.Query) (*orm.Query, error) {
+				q, err = g.Appender()(q)
+				return q, err
+			})
+			return q, err
+		})
+		return q, err
+	}
+	q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q, err = g.Appender()(q)
+		return q, err
+	})
+	return q, err
+}
+
+// decodeValues decodes the JSON parameter value, the group must be initialized with a factory.
+func (g *Group) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	if _, ok := paramValue(values, param); ok && g.factory == nil {
+		return false, decodeError(param, errors.New("filter group is not initialized with a factory"))
+	}
+	return decodeJSON(param, values, g)
+}
+
+func (g *Group) isZero() bool {
+	for _, item := range g.items {
+		if !isNil(item.filter) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Group", func() {
+
+	type GroupTestItem struct {
+		Id   int64
+		Name string
+		Age  int
+	}
+
+	factory := func(field string) (pgquery.Filter, error) {
+		switch field {
+		case "name":
+			return pgquery.NewMatch("name"), nil
+		case "age":
+			return pgquery.NewRange("age"), nil
+		}
+		return nil, fmt.Errorf("unknown field %s", field)
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			g := pgquery.Or().
+				Field("name", pgquery.NewMatch("name").Matches("name-1")).
+				Add(pgquery.Not().Field("age", pgquery.NewRange("age").GreaterThan(5)))
+
+			b, err := json.Marshal(g)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`{"or":[{"name":"name-1"},{"not":[{"age":{"gt":5}}]}]}`))
+		})
+
+		When("field is not set", func() {
+			It("should return error", func() {
+				g := pgquery.And(pgquery.NewMatch("name").Matches("name-1"))
+
+				_, err := json.Marshal(g)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		It("should unmarshal json successfully", func() {
+			g := pgquery.And().Factory(factory)
+
+			err := json.Unmarshal([]byte(`{"or":[{"name":"name-1"},{"not":{"age":{"gt":5}}}]}`), g)
+			Expect(err).ToNot(HaveOccurred())
+
+			b, err := json.Marshal(g)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`{"or":[{"name":"name-1"},{"not":[{"age":{"gt":5}}]}]}`))
+		})
+
+		When("using multiple fields", func() {
+			It("should unmarshal json as and group", func() {
+				g := pgquery.Or().Factory(factory)
+
+				err := json.Unmarshal([]byte(`{"name":"name-1","age":{"gt":5}}`), g)
+				Expect(err).ToNot(HaveOccurred())
+
+				b, err := json.Marshal(g)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b).To(MatchJSON(`{"and":[{"age":{"gt":5}},{"name":"name-1"}]}`))
+			})
+		})
+
+		When("factory is not set", func() {
+			It("should return error", func() {
+				g := pgquery.And()
+
+				err := json.Unmarshal([]byte(`{"or":[{"name":"name-1"}]}`), g)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("field is unknown", func() {
+			It("should return error", func() {
+				g := pgquery.And().Factory(factory)
+
+				err := json.Unmarshal([]byte(`{"or":[{"password":"secret"}]}`), g)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &GroupTestItem{})
+
+			g := pgquery.And(
+				pgquery.Or(
+					pgquery.NewMatch("name").Matches("name-1"),
+					pgquery.NewRange("age").GreaterThan(5),
+				),
+				pgquery.Not(pgquery.NewMatch("name").Matches("name-7")),
+			)
+			q, err := g.Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "group_test_item"."id", "group_test_item"."name", "group_test_item"."age" FROM "group_test_items" AS "group_test_item" WHERE ((((("name" = 'name-1')) OR ((("age" > 5))))) AND (((TRUE) AND NOT ((("name" = 'name-7'))))))`))
+		})
+
+		When("using not group", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &GroupTestItem{})
+
+				q, err := pgquery.Not(pgquery.NewMatch("name").Matches("name-7")).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "group_test_item"."id", "group_test_item"."name", "group_test_item"."age" FROM "group_test_items" AS "group_test_item" WHERE ((TRUE) AND NOT ((("name" = 'name-7'))))`))
+			})
+		})
+
+		When("filter returns error", func() {
+			It("should return the error", func() {
+				for _, g := range []*pgquery.Group{
+					pgquery.Or(pgquery.NewRegex("name").Pattern("(")),
+					pgquery.And(pgquery.NewMatch("name").Matches("name-1"), pgquery.Or(pgquery.NewRegex("name").Pattern("("))),
+					pgquery.Not(pgquery.NewMatch("name").Empty(pgquery.MatchEmptyError).Matches()),
+				} {
+					q := orm.NewQuery(nil, &GroupTestItem{})
+
+					_, err := g.Apply(q)
+					Expect(err).To(HaveOccurred())
+				}
+			})
+
+			It("should return the error when binding", func() {
+				q := orm.NewQuery(nil, &GroupTestItem{})
+
+				_, err := pgquery.Bind(q, &struct {
+					Filters *pgquery.Group
+				}{pgquery.And(pgquery.NewMatch("name").Empty(pgquery.MatchEmptyError).Matches())})
+				Expect(errors.Is(err, pgquery.ErrEmptyMatch)).To(BeTrue())
+			})
+		})
+
+		When("group has nil filters", func() {
+			It("should skip nil filters", func() {
+				q := orm.NewQuery(nil, &GroupTestItem{})
+
+				var m *pgquery.Match
+				var r *pgquery.Range
+
+				q, err := pgquery.Or(m, pgquery.NewMatch("name").Matches("name-1"), r).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "group_test_item"."id", "group_test_item"."name", "group_test_item"."age" FROM "group_test_items" AS "group_test_item" WHERE ((("name" = 'name-1')))`))
+			})
+		})
+
+		When("group is empty", func() {
+			It("should not generate where clause", func() {
+				q := orm.NewQuery(nil, &GroupTestItem{})
+
+				q, err := pgquery.Or().Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "group_test_item"."id", "group_test_item"."name", "group_test_item"."age" FROM "group_test_items" AS "group_test_item"`))
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*GroupTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &GroupTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+					Age:  itemCount,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with nested groups", func() {
+			var items []GroupTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.And(
+				pgquery.Or(
+					pgquery.NewMatch("name").Matches("name-1"),
+					pgquery.NewRange("age").GreaterThan(7),
+				),
+				pgquery.Not(pgquery.NewMatch("name").Matches("name-9")),
+			).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Order("id").Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(3)) {
+				Expect(items[0].Name).To(Equal("name-1"))
+				Expect(items[1].Name).To(Equal("name-8"))
+				Expect(items[2].Name).To(Equal("name-10"))
+			}
+		})
+	})
+})
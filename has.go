@@ -0,0 +1,213 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
+)
+
+// Has relation existence common filter, renders EXISTS (or NOT EXISTS) of a correlated subquery
+// on a go-pg relation (has-one, belongs-to, has-many or many-to-many) of the query model.
+type Has struct {
+	relation string
+	factory  FilterFactory
+	Value    *bool  `json:"value,omitempty"`
+	Filters  *Group `json:"where,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler. The "where" filters are initialized with the factory.
+func (f *Has) UnmarshalJSON(b []byte) error {
+	m1 := struct {
+		Value *bool           `json:"value"`
+		Where json.RawMessage `json:"where"`
+	}{}
+	var m2 *bool
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Value = m1.Value
+		if m1.Where != nil {
+			g := And().Factory(f.factory)
+			if err := json.Unmarshal(m1.Where, g); err != nil {
+				return err
+			}
+			f.Filters = g
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Value = m2
+		return nil
+	}
+
+	return errors.New("[Has]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *Has) MarshalJSON() ([]byte, error) {
+	type alias Has
+
+	if f.Filters == nil {
+		return json.Marshal(f.Value)
+	}
+	return json.Marshal((*alias)(f))
+}
+
+// NewHas initializes a new has filter, the relation is the go-pg relation field name, e.g. "Orders".
+func NewHas(relation string) *Has {
+	return &Has{
+		relation: relation,
+	}
+}
+
+// Relation set the relation for the has filter.
+func (f *Has) Relation(relation string) *Has {
+	f.relation = relation
+	return f
+}
+
+// Factory set the filter factory used to initialize the related filters when unmarshalling json.
+func (f *Has) Factory(factory FilterFactory) *Has {
+	f.factory = factory
+	return f
+}
+
+// Where adds filter(s) applied to the related rows.
+func (f *Has) Where(filters ...Filter) *Has {
+	if f.Filters == nil {
+		f.Filters = And()
+	}
+	f.Filters.Add(filters...)
+	return f
+}
+
+// Has set value.
+func (f *Has) Has(value bool) *Has {
+	f.Value = &value
+	return f
+}
+
+// ShouldHave set value to true.
+func (f *Has) ShouldHave() *Has {
+	return f.Has(true)
+}
+
+// ShouldNotHave set value to false.
+func (f *Has) ShouldNotHave() *Has {
+	return f.Has(false)
+}
+
+// lookupRelation returns the relation by field name, e.g. "Orders", or column name, e.g. "orders".
+func (f *Has) lookupRelation(table *orm.Table) (*orm.Relation, error) {
+	if rel, ok := table.Relations[f.relation]; ok {
+		return rel, nil
+	}
+	for _, rel := range table.Relations {
+		if rel.Field.SQLName == f.relation {
+			return rel, nil
+		}
+	}
+	return nil, fmt.Errorf("[Has]: unknown relation %q on %s", f.relation, table.TypeName)
+}
+
+// subquery returns the correlated subquery selecting the related rows of the query model.
+func (f *Has) subquery(q *orm.Query) (*orm.Query, error) {
+	model := q.TableModel()
+	if model == nil {
+		return nil, errors.New("[Has]: query has no model")
+	}
+	table := model.Table()
+	rel, err := f.lookupRelation(table)
+	if err != nil {
+		return nil, err
+	}
+	joinTable := rel.JoinTable
+	if joinTable.Alias == table.Alias {
+		return nil, fmt.Errorf("[Has]: relation %q has the same alias %s as the query model", f.relation, table.Alias)
+	}
+
+	sub := q.New().Model(reflect.New(joinTable.Type).Interface()).ColumnExpr("1")
+	switch rel.Type {
+	case orm.Many2ManyRelation:
+		sub.TableExpr("? AS ?", rel.M2MTableName, rel.M2MTableAlias)
+		for i, col := range rel.M2MBaseFKs {
+			sub.Where("?.? = ?.?", rel.M2MTableAlias, types.Ident(col), table.Alias, table.PKs[i].Column)
+		}
+		for i, col := range rel.M2MJoinFKs {
+			sub.Where("?.? = ?.?", joinTable.Alias, joinTable.PKs[i].Column, rel.M2MTableAlias, types.Ident(col))
+		}
+	default:
+		for i, baseFK := range rel.BaseFKs {
+			sub.Where("?.? = ?.?", joinTable.Alias, rel.JoinFKs[i].Column, table.Alias, baseFK.Column)
+		}
+		if rel.Polymorphic != nil {
+			sub.Where("?.? IN (?, ?)", joinTable.Alias, rel.Polymorphic.Column, table.ModelName, table.TypeName)
+		}
+	}
+
+	if f.Filters != nil {
+		return f.Filters.Apply(sub)
+	}
+	return sub, nil
+}
+
+// Appender returns parameters for cond group appender.
+func (f *Has) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		sub, err := f.subquery(q)
+		if err != nil {
+			return q, err
+		}
+		if f.Value != nil && !*f.Value {
+			return q.Where("NOT EXISTS (?)", sub), nil
+		}
+		return q.Where("EXISTS (?)", sub), nil
+	}
+}
+
+// Apply applies the has filter to the query.
+func (f *Has) Apply(q *orm.Query) (*orm.Query, error) {
+	return f.Appender()(q)
+}
+
+func (f *Has) isZero() bool {
+	return f.Value == nil && (f.Filters == nil || f.Filters.isZero())
+}
+
+func (f *Has) bind(opts *tagOptions) error {
+	if err := opts.allow(); err != nil {
+		return err
+	}
+	f.relation = opts.columnFor(sqlColumn{name: f.relation}).name
+	return nil
+}
+
+func (f *Has) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	v, ok := paramValue(values, param)
+	if !ok {
+		return false, nil
+	}
+	has, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, decodeError(param, errors.New("expected a boolean"))
+	}
+	f.Has(has)
+	return true, nil
+}
+
+func (f *Has) encodeValues(param string, values url.Values) error {
+	if f.Value != nil {
+		values.Set(param, strconv.FormatBool(*f.Value))
+	}
+	return nil
+}
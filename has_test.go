@@ -0,0 +1,264 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Has", func() {
+
+	type HasTestOrderLine struct {
+		Id      int64
+		OrderId int64
+		Sku     string
+	}
+
+	type HasTestOrder struct {
+		Id     int64
+		ItemId int64
+		Status string
+		Lines  []*HasTestOrderLine `pg:"rel:has-many,join_fk:order_id"`
+	}
+
+	type HasTestProfile struct {
+		Id     int64
+		ItemId int64
+		Bio    string
+	}
+
+	type HasTestTag struct {
+		Id   int64
+		Name string
+	}
+
+	type HasTestItemTag struct {
+		ItemId int64
+		TagId  int64
+	}
+
+	type HasTestItem struct {
+		Id      int64
+		Name    string
+		Orders  []*HasTestOrder `pg:"rel:has-many,join_fk:item_id"`
+		Profile *HasTestProfile `pg:"rel:belongs-to,join_fk:item_id"`
+		Tags    []*HasTestTag   `pg:"many2many:has_test_item_tags,fk:item_id,join_fk:tag_id"`
+	}
+
+	orm.RegisterTable((*HasTestItemTag)(nil))
+
+	factory := func(field string) (pgquery.Filter, error) {
+		switch field {
+		case "status":
+			return pgquery.NewMatch(field), nil
+		default:
+			return nil, nil
+		}
+	}
+
+	Context("marshalling json", func() {
+		When("value is set", func() {
+			It("should marshal json successfully", func() {
+				f := pgquery.NewHas("Orders").ShouldNotHave()
+
+				b, err := json.Marshal(f)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b).To(MatchJSON(`false`))
+			})
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		When("using object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewHas("Orders").Factory(factory)
+
+				err := json.Unmarshal([]byte(`{"value":true,"where":{"status":"paid"}}`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(*f.Value).To(BeTrue())
+
+				b, err := json.Marshal(f)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b).To(MatchJSON(`{"value":true,"where":{"and":[{"status":"paid"}]}}`))
+			})
+		})
+
+		When("using non-object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewHas("Orders")
+
+				err := json.Unmarshal([]byte(`false`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewHas("Orders").ShouldNotHave()))
+			})
+		})
+
+		When("factory is not set", func() {
+			It("should return error", func() {
+				err := json.Unmarshal([]byte(`{"where":{"status":"paid"}}`), pgquery.NewHas("Orders"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("decoding query string", func() {
+		It("should decode and encode query string values", func() {
+			req := struct {
+				Orders *pgquery.Has
+			}{}
+
+			values, err := url.ParseQuery("orders=false")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(req.Orders).To(Equal(pgquery.NewHas("").ShouldNotHave()))
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded).To(Equal(values))
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string for has many", func() {
+			q := orm.NewQuery(nil, &HasTestItem{})
+
+			q, err := pgquery.NewHas("Orders").
+				Where(pgquery.NewMatch("status").Matches("paid"), pgquery.NewHas("Lines").Where(pgquery.NewMatch("sku").Matches("sku-1"))).
+				Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "has_test_item"."id", "has_test_item"."name" FROM "has_test_items" AS "has_test_item" WHERE (EXISTS (SELECT 1 FROM "has_test_orders" AS "has_test_order" WHERE ("has_test_order"."item_id" = "has_test_item"."id") AND ((("status" = 'paid')) AND ((EXISTS (SELECT 1 FROM "has_test_order_lines" AS "has_test_order_line" WHERE ("has_test_order_line"."order_id" = "has_test_order"."id") AND ((("sku" = 'sku-1')))))))))`))
+		})
+
+		It("should generate correct SQL string for belongs to", func() {
+			q := orm.NewQuery(nil, &HasTestItem{})
+
+			q, err := pgquery.NewHas("profile").ShouldNotHave().Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "has_test_item"."id", "has_test_item"."name" FROM "has_test_items" AS "has_test_item" WHERE (NOT EXISTS (SELECT 1 FROM "has_test_profiles" AS "has_test_profile" WHERE ("has_test_profile"."item_id" = "has_test_item"."id")))`))
+		})
+
+		It("should generate correct SQL string for many to many", func() {
+			q := orm.NewQuery(nil, &HasTestItem{})
+
+			q, err := pgquery.NewHas("Tags").ShouldHave().Where(pgquery.NewMatch("has_test_tag.name").Matches("vip")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "has_test_item"."id", "has_test_item"."name" FROM "has_test_items" AS "has_test_item" WHERE (EXISTS (SELECT 1 FROM "has_test_tags" AS "has_test_tag", "has_test_item_tags" AS "has_test_item_tag" WHERE ("has_test_item_tag"."item_id" = "has_test_item"."id") AND ("has_test_tag"."id" = "has_test_item_tag"."tag_id") AND ((("has_test_tag"."name" = 'vip')))))`))
+		})
+
+		When("related filter returns error", func() {
+			It("should return the error", func() {
+				q := orm.NewQuery(nil, &HasTestItem{})
+
+				_, err := pgquery.NewHas("Orders").Where(pgquery.NewRegex("status").Pattern("(")).Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("using unknown relation", func() {
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &HasTestItem{})
+
+				_, err := pgquery.NewHas("Unknown").ShouldHave().Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*HasTestItem)(nil), (*HasTestOrder)(nil), (*HasTestOrderLine)(nil), (*HasTestProfile)(nil), (*HasTestTag)(nil), (*HasTestItemTag)(nil))
+
+			tag := &HasTestTag{Name: "vip"}
+			_, err := db.Model(tag).Insert()
+			Expect(err).ToNot(HaveOccurred())
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &HasTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+				}
+				_, err = db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+
+				status := "pending"
+				if itemCount%2 == 0 {
+					status = "paid"
+				}
+				_, err = db.Model(&HasTestOrder{ItemId: item.Id, Status: status}).Insert()
+				Expect(err).ToNot(HaveOccurred())
+
+				if itemCount <= 4 {
+					_, err = db.Model(&HasTestProfile{ItemId: item.Id}).Insert()
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				if itemCount <= 3 {
+					_, err = db.Model(&HasTestItemTag{ItemId: item.Id, TagId: tag.Id}).Insert()
+					Expect(err).ToNot(HaveOccurred())
+				}
+			}
+		})
+
+		It("works with has many", func() {
+			var items []HasTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewHas("Orders").Where(pgquery.NewMatch("status").Matches("paid")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(5))
+		})
+
+		It("works with not exists", func() {
+			var items []HasTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewHas("Profile").ShouldNotHave().Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(6))
+		})
+
+		It("works with many to many", func() {
+			var items []HasTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewHas("Tags").Where(pgquery.NewMatch("has_test_tag.name").Matches("vip")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+	})
+})
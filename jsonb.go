@@ -0,0 +1,563 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
+)
+
+// jsonbCasts types allowed for casting text extracted from jsonb.
+var jsonbCasts = map[string]bool{
+	"text":             true,
+	"numeric":          true,
+	"integer":          true,
+	"bigint":           true,
+	"double precision": true,
+	"boolean":          true,
+	"date":             true,
+	"timestamp":        true,
+	"timestamptz":      true,
+	"uuid":             true,
+}
+
+// buildJSONBColumn returns the column, or the jsonb sub-document at the path. Path segments are
+// bound as a text[] literal, so they are escaped the same way as any other value.
+func buildJSONBColumn(column sqlColumn, path []string) interface{} {
+	if len(path) <= 0 {
+		return buildIdent(column)
+	}
+	return orm.SafeQuery("(? #> ?::text[])", buildIdent(column), types.NewArray(path))
+}
+
+func parseJSONBPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// JSONBContains jsonb containment (@>) common filter.
+type JSONBContains struct {
+	column sqlColumn
+	path   []string
+	Value  interface{} `json:"value,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler, the whole JSON document is the contained value.
+func (f *JSONBContains) UnmarshalJSON(b []byte) error {
+	var m1 interface{}
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Value = m1
+		return nil
+	}
+
+	return errors.New("[JSONBContains]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *JSONBContains) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Value)
+}
+
+// NewJSONBContains initializes a new jsonb contains filter.
+func NewJSONBContains(column string) *JSONBContains {
+	return &JSONBContains{
+		column: sqlColumn{name: column},
+	}
+}
+
+// Column set the column for the jsonb contains filter.
+func (f *JSONBContains) Column(column string) *JSONBContains {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Path set the path of the sub-document for the jsonb contains filter.
+func (f *JSONBContains) Path(path ...string) *JSONBContains {
+	f.path = path
+	return f
+}
+
+// Contains set value, marshalled to JSON.
+func (f *JSONBContains) Contains(value interface{}) *JSONBContains {
+	f.Value = value
+	return f
+}
+
+// Appender returns parameters for cond appender.
+func (f *JSONBContains) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		b, err := json.Marshal(f.Value)
+		if err != nil {
+			return q, fmt.Errorf("[JSONBContains]: %v", err)
+		}
+		return q.Where("? @> ?::jsonb", buildJSONBColumn(f.column, f.path), string(b)), nil
+	}
+}
+
+// Apply applies the jsonb contains filter to the query.
+func (f *JSONBContains) Apply(q *orm.Query) (*orm.Query, error) {
+	return f.Appender()(q)
+}
+
+func (f *JSONBContains) isZero() bool {
+	return f.Value == nil
+}
+
+func (f *JSONBContains) bind(opts *tagOptions) error {
+	if err := opts.allow("path"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("path") {
+		f.Path(parseJSONBPath(opts.get("path"))...)
+	}
+	return nil
+}
+
+// JSONBHasKey jsonb key existence (?, ?|, ?&) common filter.
+type JSONBHasKey struct {
+	column sqlColumn
+	path   []string
+	all    bool
+	Keys   []string `json:"keys,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler.
+func (f *JSONBHasKey) UnmarshalJSON(b []byte) error {
+	type alias JSONBHasKey
+
+	m1 := alias{}
+	var m2 []string
+	var m3 string
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Keys = m1.Keys
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Keys = m2
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m3); err == nil {
+		f.Keys = []string{m3}
+		return nil
+	}
+
+	return errors.New("[JSONBHasKey]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *JSONBHasKey) MarshalJSON() ([]byte, error) {
+	switch {
+	case len(f.Keys) == 1:
+		return json.Marshal(f.Keys[0])
+	default:
+		return json.Marshal(f.Keys)
+	}
+}
+
+// NewJSONBHasKey initializes a new jsonb has key filter.
+func NewJSONBHasKey(column string) *JSONBHasKey {
+	return &JSONBHasKey{
+		column: sqlColumn{name: column},
+	}
+}
+
+// Column set the column for the jsonb has key filter.
+func (f *JSONBHasKey) Column(column string) *JSONBHasKey {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Path set the path of the sub-document for the jsonb has key filter.
+func (f *JSONBHasKey) Path(path ...string) *JSONBHasKey {
+	f.path = path
+	return f
+}
+
+// Any set any of the keys to exist (?|) for the jsonb has key filter, the default.
+func (f *JSONBHasKey) Any() *JSONBHasKey {
+	f.all = false
+	return f
+}
+
+// All set all of the keys to exist (?&) for the jsonb has key filter.
+func (f *JSONBHasKey) All() *JSONBHasKey {
+	f.all = true
+	return f
+}
+
+// HasKeys set key(s).
+func (f *JSONBHasKey) HasKeys(keys ...string) *JSONBHasKey {
+	f.Keys = append(f.Keys, keys...)
+	return f
+}
+
+// Appender returns parameters for cond appender. The ? operators are escaped from go-pg placeholders.
+func (f *JSONBHasKey) Appender() (string, interface{}, interface{}) {
+	column := buildJSONBColumn(f.column, f.path)
+	switch {
+	case len(f.Keys) == 1:
+		return `? \? ?`, column, f.Keys[0]
+	case f.all:
+		return `? \?& ?::text[]`, column, types.NewArray(f.Keys)
+	default:
+		return `? \?| ?::text[]`, column, types.NewArray(f.Keys)
+	}
+}
+
+// Apply applies the jsonb has key filter to the query.
+func (f *JSONBHasKey) Apply(q *orm.Query) (*orm.Query, error) {
+	return q.Where(f.Appender()), nil
+}
+
+func (f *JSONBHasKey) isZero() bool {
+	return len(f.Keys) == 0
+}
+
+func (f *JSONBHasKey) bind(opts *tagOptions) error {
+	if err := opts.allow("path", "any", "all"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("path") {
+		f.Path(parseJSONBPath(opts.get("path"))...)
+	}
+	if opts.has("any") {
+		f.Any()
+	}
+	if opts.has("all") {
+		f.All()
+	}
+	return nil
+}
+
+func (f *JSONBHasKey) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	list := paramList(values, param)
+	if len(list) == 0 {
+		return false, nil
+	}
+	f.Keys = list
+	return true, nil
+}
+
+func (f *JSONBHasKey) encodeValues(param string, values url.Values) error {
+	values.Set(param, strings.Join(f.Keys, ","))
+	return nil
+}
+
+// JSONBValue jsonb typed value comparison common filter. The value at the path is extracted as
+// text (#>>) and cast to the type, e.g. numeric, before it is compared.
+type JSONBValue struct {
+	column sqlColumn
+	path   []string
+	cast   string
+	Eq     interface{} `json:"eq,omitempty"`
+	Gt     interface{} `json:"gt,omitempty"`
+	Gte    interface{} `json:"gte,omitempty"`
+	Lt     interface{} `json:"lt,omitempty"`
+	Lte    interface{} `json:"lte,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler, a non-object value is compared for equality.
+func (f *JSONBValue) UnmarshalJSON(b []byte) error {
+	type alias JSONBValue
+
+	m1 := alias{}
+	var m2 interface{}
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Eq, f.Gt, f.Gte, f.Lt, f.Lte = m1.Eq, m1.Gt, m1.Gte, m1.Lt, m1.Lte
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Eq = m2
+		return nil
+	}
+
+	return errors.New("[JSONBValue]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *JSONBValue) MarshalJSON() ([]byte, error) {
+	type alias JSONBValue
+
+	if f.Eq != nil && f.Gt == nil && f.Gte == nil && f.Lt == nil && f.Lte == nil {
+		return json.Marshal(f.Eq)
+	}
+	return json.Marshal((*alias)(f))
+}
+
+// NewJSONBValue initializes a new jsonb value filter.
+func NewJSONBValue(column string, path ...string) *JSONBValue {
+	return &JSONBValue{
+		column: sqlColumn{name: column},
+		path:   path,
+	}
+}
+
+// Column set the column for the jsonb value filter.
+func (f *JSONBValue) Column(column string) *JSONBValue {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Path set the path of the value for the jsonb value filter.
+func (f *JSONBValue) Path(path ...string) *JSONBValue {
+	f.path = path
+	return f
+}
+
+// Cast set the type the value is cast to for the jsonb value filter, e.g. numeric, boolean,
+// timestamptz. Values are compared as text when not set.
+func (f *JSONBValue) Cast(cast string) *JSONBValue {
+	f.cast = cast
+	return f
+}
+
+// Equal set value for equal (eq).
+func (f *JSONBValue) Equal(value interface{}) *JSONBValue {
+	f.Eq = value
+	return f
+}
+
+// GreaterThan set value for greater than (gt).
+func (f *JSONBValue) GreaterThan(value interface{}) *JSONBValue {
+	f.Gt = value
+	return f
+}
+
+// GreaterThanEqual set value for greater than equal (gte).
+func (f *JSONBValue) GreaterThanEqual(value interface{}) *JSONBValue {
+	f.Gte = value
+	return f
+}
+
+// LessThan set value for less than (lt).
+func (f *JSONBValue) LessThan(value interface{}) *JSONBValue {
+	f.Lt = value
+	return f
+}
+
+// LessThanEqual set value for less than equal (lte).
+func (f *JSONBValue) LessThanEqual(value interface{}) *JSONBValue {
+	f.Lte = value
+	return f
+}
+
+func (f *JSONBValue) buildColumn() interface{} {
+	column := orm.SafeQuery("? #>> ?::text[]", buildIdent(f.column), types.NewArray(f.path))
+	if f.cast == "" {
+		return orm.SafeQuery("(?)", column)
+	}
+	return orm.SafeQuery("(?)::?", column, types.Safe(f.cast))
+}
+
+func (f *JSONBValue) buildValue(value interface{}) interface{} {
+	if f.cast == "" {
+		return orm.SafeQuery("?::text", value)
+	}
+	return orm.SafeQuery("?::?", value, types.Safe(f.cast))
+}
+
+// Appender returns parameters for cond group appender.
+func (f *JSONBValue) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		if f.cast != "" && !jsonbCasts[f.cast] {
+			return q, fmt.Errorf("[JSONBValue]: unsupported cast %q", f.cast)
+		}
+		if len(f.path) <= 0 {
+			return q, errors.New("[JSONBValue]: path is not specified")
+		}
+		column := f.buildColumn()
+		if f.Eq != nil {
+			q.Where("? = ?", column, f.buildValue(f.Eq))
+		}
+		if f.Lt != nil {
+			q.Where("? < ?", column, f.buildValue(f.Lt))
+		}
+		if f.Lte != nil {
+			q.Where("? <= ?", column, f.buildValue(f.Lte))
+		}
+		if f.Gte != nil {
+			q.Where("? >= ?", column, f.buildValue(f.Gte))
+		}
+		if f.Gt != nil {
+			q.Where("? > ?", column, f.buildValue(f.Gt))
+		}
+		return q, nil
+	}
+}
+
+// Apply applies the jsonb value filter to the query.
+func (f *JSONBValue) Apply(q *orm.Query) (*orm.Query, error) {
+	var err error
+	q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q, err = f.Appender()(q)
+		return q, err
+	})
+	return q, err
+}
+
+func (f *JSONBValue) isZero() bool {
+	return f.Eq == nil && f.Gt == nil && f.Gte == nil && f.Lt == nil && f.Lte == nil
+}
+
+func (f *JSONBValue) bind(opts *tagOptions) error {
+	if err := opts.allow("path", "cast"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("path") {
+		f.Path(parseJSONBPath(opts.get("path"))...)
+	}
+	if opts.has("cast") {
+		f.Cast(opts.get("cast"))
+	}
+	return nil
+}
+
+func (f *JSONBValue) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	bounds := []struct {
+		key   string
+		value *interface{}
+	}{
+		{"", &f.Eq},
+		{"[eq]", &f.Eq},
+		{"[gt]", &f.Gt},
+		{"[gte]", &f.Gte},
+		{"[lt]", &f.Lt},
+		{"[lte]", &f.Lte},
+	}
+	found := false
+	for _, bound := range bounds {
+		if v, ok := paramValue(values, param+bound.key); ok {
+			*bound.value = v
+			found = true
+		}
+	}
+	return found, nil
+}
+
+func (f *JSONBValue) encodeValues(param string, values url.Values) error {
+	bounds := []struct {
+		key   string
+		value interface{}
+	}{
+		{"eq", f.Eq},
+		{"gt", f.Gt},
+		{"gte", f.Gte},
+		{"lt", f.Lt},
+		{"lte", f.Lte},
+	}
+	for _, bound := range bounds {
+		if bound.value != nil {
+			values.Set(param+"["+bound.key+"]", fmt.Sprint(bound.value))
+		}
+	}
+	return nil
+}
+
+// JSONBPath SQL/JSON path (@?, @@) common filter.
+type JSONBPath struct {
+	column    sqlColumn
+	predicate bool
+	Value     *string `json:"value,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler.
+func (f *JSONBPath) UnmarshalJSON(b []byte) error {
+	type alias JSONBPath
+
+	m1 := alias{}
+	var m2 *string
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Value = m1.Value
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Value = m2
+		return nil
+	}
+
+	return errors.New("[JSONBPath]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *JSONBPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Value)
+}
+
+// NewJSONBPath initializes a new jsonb path filter.
+func NewJSONBPath(column string) *JSONBPath {
+	return &JSONBPath{
+		column: sqlColumn{name: column},
+	}
+}
+
+// Column set the column for the jsonb path filter.
+func (f *JSONBPath) Column(column string) *JSONBPath {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Predicate set the path to be a predicate check (@@) for the jsonb path filter, e.g.
+// "$.price > 10". The path returns any item (@?) by default, e.g. "$.tags[*] ? (@ == "sale")".
+func (f *JSONBPath) Predicate() *JSONBPath {
+	f.predicate = true
+	return f
+}
+
+// JSONPath set value.
+func (f *JSONBPath) JSONPath(path string) *JSONBPath {
+	f.Value = &path
+	return f
+}
+
+// Appender returns parameters for cond appender. The ? operator is escaped from go-pg placeholders.
+func (f *JSONBPath) Appender() (string, interface{}, interface{}) {
+	var v string
+	if f.Value != nil {
+		v = *f.Value
+	}
+	if f.predicate {
+		return "? @@ ?::jsonpath", buildIdent(f.column), v
+	}
+	return `? @\? ?::jsonpath`, buildIdent(f.column), v
+}
+
+// Apply applies the jsonb path filter to the query.
+func (f *JSONBPath) Apply(q *orm.Query) (*orm.Query, error) {
+	return q.Where(f.Appender()), nil
+}
+
+func (f *JSONBPath) isZero() bool {
+	return f.Value == nil
+}
+
+func (f *JSONBPath) bind(opts *tagOptions) error {
+	if err := opts.allow("predicate"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("predicate") {
+		f.Predicate()
+	}
+	return nil
+}
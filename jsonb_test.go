@@ -0,0 +1,212 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type JSONBTestItem struct {
+	Id    int64
+	Attrs map[string]interface{}
+}
+
+var _ = Describe("JSONB", func() {
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			for f, expected := range map[pgquery.Filter]string{
+				pgquery.NewJSONBContains("").Contains(map[string]interface{}{"color": "red"}): `{"color":"red"}`,
+				pgquery.NewJSONBHasKey("").HasKeys("color"):                                   `"color"`,
+				pgquery.NewJSONBHasKey("").HasKeys("color", "size"):                           `["color","size"]`,
+				pgquery.NewJSONBValue("").Equal("red"):                                        `"red"`,
+				pgquery.NewJSONBValue("").GreaterThan(1).LessThanEqual(5):                     `{"gt":1,"lte":5}`,
+				pgquery.NewJSONBPath("").JSONPath("$.size > 1"):                               `"$.size > 1"`,
+			} {
+				b, err := json.Marshal(f)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b).To(MatchJSON(expected))
+			}
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		It("should unmarshal json successfully", func() {
+			contains := pgquery.NewJSONBContains("")
+			err := json.Unmarshal([]byte(`{"color":"red"}`), contains)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contains).To(Equal(pgquery.NewJSONBContains("").Contains(map[string]interface{}{"color": "red"})))
+
+			hasKey := pgquery.NewJSONBHasKey("")
+			err = json.Unmarshal([]byte(`{"keys":["color","size"]}`), hasKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hasKey).To(Equal(pgquery.NewJSONBHasKey("").HasKeys("color", "size")))
+
+			hasKey = pgquery.NewJSONBHasKey("")
+			err = json.Unmarshal([]byte(`"color"`), hasKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hasKey).To(Equal(pgquery.NewJSONBHasKey("").HasKeys("color")))
+
+			value := pgquery.NewJSONBValue("")
+			err = json.Unmarshal([]byte(`{"gte":1,"lt":5}`), value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(pgquery.NewJSONBValue("").GreaterThanEqual(1.0).LessThan(5.0)))
+
+			value = pgquery.NewJSONBValue("")
+			err = json.Unmarshal([]byte(`true`), value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(pgquery.NewJSONBValue("").Equal(true)))
+
+			path := pgquery.NewJSONBPath("")
+			err = json.Unmarshal([]byte(`{"value":"$.size > 1"}`), path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal(pgquery.NewJSONBPath("").JSONPath("$.size > 1")))
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate containment SQL string", func() {
+			q := orm.NewQuery(nil, &JSONBTestItem{})
+
+			q, err := pgquery.NewJSONBContains("attrs").Path("dimension").Contains(map[string]interface{}{"unit": "cm"}).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "jsonb_test_item"."id", "jsonb_test_item"."attrs" FROM "jsonb_test_items" AS "jsonb_test_item" WHERE (("attrs" #> '{"dimension"}'::text[]) @> '{"unit":"cm"}'::jsonb)`))
+		})
+
+		It("should generate key existence SQL string", func() {
+			q := orm.NewQuery(nil, &JSONBTestItem{})
+
+			q.Where(pgquery.NewJSONBHasKey("attrs").HasKeys("color").Appender())
+			q.Where(pgquery.NewJSONBHasKey("attrs").HasKeys("size", "weight").Appender())
+			q.Where(pgquery.NewJSONBHasKey("attrs").HasKeys("size", "weight").All().Appender())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "jsonb_test_item"."id", "jsonb_test_item"."attrs" FROM "jsonb_test_items" AS "jsonb_test_item" WHERE ("attrs" ? 'color') AND ("attrs" ?| '{"size","weight"}'::text[]) AND ("attrs" ?& '{"size","weight"}'::text[])`))
+		})
+
+		It("should generate typed comparison SQL string", func() {
+			q := orm.NewQuery(nil, &JSONBTestItem{})
+
+			q, err := pgquery.NewJSONBValue("attrs", "dimension", "width").Cast("numeric").GreaterThan(5).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "jsonb_test_item"."id", "jsonb_test_item"."attrs" FROM "jsonb_test_items" AS "jsonb_test_item" WHERE ((("attrs" #>> '{"dimension","width"}'::text[])::numeric > 5::numeric))`))
+		})
+
+		It("should escape path segments", func() {
+			q := orm.NewQuery(nil, &JSONBTestItem{})
+
+			q, err := pgquery.NewJSONBValue("attrs", `a"b`, "c'd", `e\f`).Equal("x").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "jsonb_test_item"."id", "jsonb_test_item"."attrs" FROM "jsonb_test_items" AS "jsonb_test_item" WHERE ((("attrs" #>> '{"a\"b","c''d","e\\f"}'::text[]) = 'x'::text))`))
+		})
+
+		It("should reject unsupported cast", func() {
+			q := orm.NewQuery(nil, &JSONBTestItem{})
+
+			_, err := pgquery.NewJSONBValue("attrs", "size").Cast("numeric); DROP TABLE x; --").Equal(1).Apply(q)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should generate jsonpath SQL string", func() {
+			q := orm.NewQuery(nil, &JSONBTestItem{})
+
+			q.Where(pgquery.NewJSONBPath("attrs").JSONPath(`$.tags[*] ? (@ == "sale")`).Appender())
+			q.Where(pgquery.NewJSONBPath("attrs").Predicate().JSONPath(`$.price > 10`).Appender())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "jsonb_test_item"."id", "jsonb_test_item"."attrs" FROM "jsonb_test_items" AS "jsonb_test_item" WHERE ("attrs" @? '$.tags[*] ? (@ == "sale")'::jsonpath) AND ("attrs" @@ '$.price > 10'::jsonpath)`))
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*JSONBTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				attrs := map[string]interface{}{
+					"name": fmt.Sprintf("name-%d", itemCount),
+					"size": itemCount,
+				}
+				if itemCount%2 == 0 {
+					attrs["color"] = "red"
+				}
+				item := &JSONBTestItem{
+					Attrs: attrs,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with containment", func() {
+			var items []JSONBTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewJSONBContains("attrs").Contains(map[string]interface{}{"color": "red"}).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(5))
+		})
+
+		It("works with key existence", func() {
+			var items []JSONBTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewJSONBHasKey("attrs").HasKeys("color", "size").All().Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(5))
+		})
+
+		It("works with typed comparison", func() {
+			var items []JSONBTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewJSONBValue("attrs", "size").Cast("numeric").GreaterThan(8).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(2))
+		})
+
+		It("works with jsonpath", func() {
+			requireJSONPath()
+
+			var items []JSONBTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewJSONBPath("attrs").Predicate().JSONPath(`$.size <= 3`).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+	})
+})
@@ -7,6 +7,7 @@ package pgquery
 import (
 	"encoding/json"
 	"errors"
+	"net/url"
 	"strings"
 
 	"github.com/go-pg/pg/v10/orm"
@@ -15,7 +16,7 @@ import (
 
 // KeywordSearch keyword search common filter.
 type KeywordSearch struct {
-	column          string
+	column          sqlColumn
 	caseInsensitive bool
 	matchAll        bool
 	matchStart      bool
@@ -51,13 +52,13 @@ func (f *KeywordSearch) MarshalJSON() ([]byte, error) {
 // NewKeywordSearch initializes a new keyword search filter.
 func NewKeywordSearch(column string) *KeywordSearch {
 	return &KeywordSearch{
-		column: column,
+		column: sqlColumn{name: column},
 	}
 }
 
 // Column set the column for the keyword search filter. Suffix column with ",array" to use array search.
 func (f *KeywordSearch) Column(column string) *KeywordSearch {
-	f.column = column
+	f.column = sqlColumn{name: column}
 	return f
 }
 
@@ -115,11 +116,12 @@ func (f *KeywordSearch) buildLike() string {
 	return "LIKE"
 }
 
-func (f *KeywordSearch) buildColumn(column string) interface{} {
-	if strings.HasSuffix(column, ",array") {
-		return orm.SafeQuery("array_to_string(?, ?)", types.Ident(strings.TrimSuffix(column, ",array")), ",")
+func (f *KeywordSearch) buildColumn(column sqlColumn) interface{} {
+	if strings.HasSuffix(column.name, ",array") {
+		column.name = strings.TrimSuffix(column.name, ",array")
+		return orm.SafeQuery("array_to_string(?, ?)", buildIdent(column), ",")
 	}
-	return types.Ident(column)
+	return buildIdent(column)
 }
 
 // Appender returns parameters for cond appender.
@@ -129,3 +131,49 @@ func (f *KeywordSearch) Appender() (string, interface{}, interface{}, interface{
 	like := f.buildLike()
 	return "? ? ?", column, types.Safe(like), v
 }
+
+// Apply applies the keyword search filter to the query.
+func (f *KeywordSearch) Apply(q *orm.Query) (*orm.Query, error) {
+	return q.Where(f.Appender()), nil
+}
+
+func (f *KeywordSearch) isZero() bool {
+	return f.Value == nil
+}
+
+func (f *KeywordSearch) bind(opts *tagOptions) error {
+	if err := opts.allow("ci", "matchAll", "matchStart", "matchEnd", "array"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("array") && !strings.HasSuffix(f.column.name, ",array") {
+		f.column.name += ",array"
+	}
+	if opts.has("ci") {
+		f.CaseInsensitive()
+	}
+	if opts.has("matchAll") {
+		f.MatchAll()
+	}
+	if opts.has("matchStart") {
+		f.MatchStart()
+	}
+	if opts.has("matchEnd") {
+		f.MatchEnd()
+	}
+	return nil
+}
+
+func (f *KeywordSearch) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	v, ok := paramValue(values, param)
+	if !ok {
+		return false, nil
+	}
+	f.Keyword(v)
+	return true, nil
+}
+
+func (f *KeywordSearch) encodeValues(param string, values url.Values) error {
+	values.Set(param, *f.Value)
+	return nil
+}
@@ -69,22 +69,23 @@ var _ = Describe("KeywordSearch", func() {
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*KeywordSearchTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			item := &KeywordSearchTestItem{
-				Name:   fmt.Sprintf("name-%d", itemCount),
-				Emails: make([]string, 0),
-			}
-			for emailCount := 1; emailCount <= 5; emailCount++ {
-				item.Emails = append(item.Emails, fmt.Sprintf("email-%d(%d)@root", itemCount, emailCount))
+			createTempTables((*KeywordSearchTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &KeywordSearchTestItem{
+					Name:   fmt.Sprintf("name-%d", itemCount),
+					Emails: make([]string, 0),
+				}
+				for emailCount := 1; emailCount <= 5; emailCount++ {
+					item.Emails = append(item.Emails, fmt.Sprintf("email-%d(%d)@root", itemCount, emailCount))
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
-			Expect(err).ToNot(HaveOccurred())
-		}
+		})
 
 		It("works with default search", func() {
 			var items []KeywordSearchTestItem
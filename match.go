@@ -5,15 +5,57 @@
 package pgquery
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 
+	"github.com/go-pg/pg/v10/orm"
 	"github.com/go-pg/pg/v10/types"
 )
 
+// matchNullParam query string value of a NULL match value, e.g. "status=null,active".
+const matchNullParam = "null"
+
+// ErrEmptyMatch the match filter has an empty value list and is set to reject it.
+var ErrEmptyMatch = errors.New("[Match]: empty values")
+
+// MatchEmpty empty value list behaviour enum type.
+type MatchEmpty int
+
+const (
+	// MatchEmptyNone match none enum, an empty value list matches no rows (FALSE), or every row
+	// when negated.
+	MatchEmptyNone MatchEmpty = iota
+
+	// MatchEmptySkip skip enum, an empty value list is not applied.
+	MatchEmptySkip
+
+	// MatchEmptyError error enum, an empty value list returns ErrEmptyMatch when applied.
+	MatchEmptyError
+)
+
+// String returns the string presentation for the empty value list behaviour.
+func (e MatchEmpty) String() string {
+	return [...]string{"none", "skip", "error"}[e]
+}
+
+func parseMatchEmpty(v string) (MatchEmpty, error) {
+	for _, e := range []MatchEmpty{MatchEmptyNone, MatchEmptySkip, MatchEmptyError} {
+		if strings.EqualFold(v, e.String()) {
+			return e, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported empty behaviour %q", v)
+}
+
 // Match match common filter.
 type Match struct {
-	column string
+	column sqlColumn
+	not    bool
+	empty  MatchEmpty
 	Values []interface{} `json:"values,omitempty"`
 }
 
@@ -28,6 +70,20 @@ func (f *Match) UnmarshalJSON(b []byte) error {
 	}{Alias: (*Alias)(f)}
 	m3 := make([]interface{}, 0)
 	var m4 interface{}
+	m5 := struct {
+		Values json.RawMessage `json:"values"`
+	}{}
+
+	// A bare null is a single NULL value, as marshalled by Matches(nil).
+	if string(bytes.TrimSpace(b)) == "null" {
+		f.Values = []interface{}{nil}
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m5); err == nil && string(m5.Values) == "null" {
+		f.Values = []interface{}{nil}
+		return nil
+	}
 
 	if err := json.Unmarshal(b, &m1); err == nil {
 		f.Values = m1.Values
@@ -65,28 +121,152 @@ func (f *Match) MarshalJSON() ([]byte, error) {
 // NewMatch initializes a new match filter.
 func NewMatch(column string) *Match {
 	return &Match{
-		column: column,
+		column: sqlColumn{name: column},
 	}
 }
 
 // Column sets the column for the match filter.
 func (f *Match) Column(column string) *Match {
-	f.column = column
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Not set negated match for the match filter, rows not matching any of the values are returned,
+// including rows where the column is NULL unless a null value is given.
+func (f *Match) Not() *Match {
+	f.not = true
+	return f
+}
+
+// Empty set the behaviour for an empty value list for the match filter, defaults to match none.
+func (f *Match) Empty(empty MatchEmpty) *Match {
+	f.empty = empty
 	return f
 }
 
-// Matches set value(s).
+// Matches set value(s), calling without values sets an empty value list.
 func (f *Match) Matches(values ...interface{}) *Match {
+	if f.Values == nil {
+		f.Values = make([]interface{}, 0, len(values))
+	}
 	f.Values = append(f.Values, values...)
 	return f
 }
 
-// Appender returns parameters for cond appender.
+// splitNull returns the non-null values and whether a null value is present.
+func (f *Match) splitNull() ([]interface{}, bool) {
+	values := make([]interface{}, 0, len(f.Values))
+	null := false
+	for _, v := range f.Values {
+		if v == nil {
+			null = true
+			continue
+		}
+		values = append(values, v)
+	}
+	return values, null
+}
+
+// Appender returns parameters for cond appender. A null value matches NULL columns, negated
+// match uses IS DISTINCT FROM semantics. An empty value list matches no rows (every row when
+// negated) unless it is skipped.
 func (f *Match) Appender() (string, interface{}, interface{}) {
+	column := buildIdent(f.column)
+	values, null := f.splitNull()
 	switch {
-	case len(f.Values) > 1:
-		return "? IN (?)", types.Ident(f.column), types.In(f.Values)
+	case len(f.Values) == 0 && (f.not || f.empty == MatchEmptySkip):
+		return "TRUE", nil, nil
+	case len(f.Values) == 0:
+		return "FALSE", nil, nil
+	case null && len(values) == 0 && f.not:
+		return "? IS DISTINCT FROM ?", column, nil
+	case null && len(values) == 0:
+		return "? IS NOT DISTINCT FROM ?", column, nil
+	case f.not && null:
+		// NOT IN never matches NULL columns.
+		return "? NOT IN (?)", column, types.In(values)
+	case f.not && len(values) == 1:
+		return "? IS DISTINCT FROM ?", column, values[0]
+	case f.not:
+		return "? IS NULL OR ?", column, orm.SafeQuery("? NOT IN (?)", column, types.In(values))
+	case null:
+		return "? IS NULL OR ?", column, orm.SafeQuery("? IN (?)", column, types.In(values))
+	case len(values) > 1:
+		return "? IN (?)", column, types.In(values)
 	default:
-		return "? = ?", types.Ident(f.column), f.Values[0]
+		return "? = ?", column, values[0]
+	}
+}
+
+// Apply applies the match filter to the query.
+func (f *Match) Apply(q *orm.Query) (*orm.Query, error) {
+	if len(f.Values) == 0 {
+		switch f.empty {
+		case MatchEmptySkip:
+			return q, nil
+		case MatchEmptyError:
+			return q, ErrEmptyMatch
+		}
+	}
+	return q.Where(f.Appender()), nil
+}
+
+func (f *Match) isZero() bool {
+	return f.Values == nil
+}
+
+func (f *Match) bind(opts *tagOptions) error {
+	if err := opts.allow("not", "empty"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("not") {
+		f.Not()
+	}
+	if opts.has("empty") {
+		empty, err := parseMatchEmpty(opts.get("empty"))
+		if err != nil {
+			return err
+		}
+		f.Empty(empty)
+	}
+	return nil
+}
+
+// decodeValues decodes the comma separated values. A blank parameter, e.g. "status=", is not set
+// unless the field is tagged with an empty mode, in which case it is an empty value list.
+func (f *Match) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	if _, ok := values[param]; !ok {
+		return false, nil
+	}
+	list := paramList(values, param)
+	if len(list) == 0 && !opts.has("empty") {
+		return false, nil
+	}
+	f.Values = make([]interface{}, 0, len(list))
+	for _, v := range list {
+		if v == matchNullParam {
+			f.Values = append(f.Values, nil)
+			continue
+		}
+		f.Values = append(f.Values, v)
+	}
+	return true, nil
+}
+
+func (f *Match) encodeValues(param string, values url.Values) error {
+	list := make([]string, 0, len(f.Values))
+	for _, v := range f.Values {
+		if v == nil {
+			list = append(list, matchNullParam)
+			continue
+		}
+		s := fmt.Sprint(v)
+		if s == matchNullParam {
+			return fmt.Errorf("[Match]: value %q is reserved for NULL in query string", s)
+		}
+		list = append(list, s)
 	}
+	values.Set(param, strings.Join(list, ","))
+	return nil
 }
@@ -6,7 +6,9 @@ package pgquery_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/go-pg/pg/v10/orm"
 	"github.com/junwen-k/pgquery"
@@ -21,6 +23,12 @@ var _ = Describe("Match", func() {
 		Name string
 	}
 
+	type MatchNullTestItem struct {
+		Id   int64
+		Name string
+		Tag  string
+	}
+
 	Context("marshalling json", func() {
 		When("using a single value", func() {
 			It("should marshal json successfully", func() {
@@ -93,9 +101,165 @@ var _ = Describe("Match", func() {
 				Expect(f).To(Equal(pgquery.NewMatch("").Matches("match")))
 			})
 		})
+
+		When("using a null value", func() {
+			It("should unmarshal json successfully", func() {
+				for _, v := range []string{`null`, `{"values":null}`} {
+					f := pgquery.NewMatch("")
+
+					err := json.Unmarshal([]byte(v), f)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(f).To(Equal(pgquery.NewMatch("").Matches(nil)), v)
+				}
+			})
+
+			It("should marshal and unmarshal json successfully", func() {
+				b, err := json.Marshal(pgquery.NewMatch("").Matches(nil))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(b).To(MatchJSON(`null`))
+
+				f := pgquery.NewMatch("tag")
+				err = json.Unmarshal(b, f)
+				Expect(err).ToNot(HaveOccurred())
+
+				q := orm.NewQuery(nil, &MatchNullTestItem{})
+
+				q, err = f.Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "match_null_test_item"."id", "match_null_test_item"."name", "match_null_test_item"."tag" FROM "match_null_test_items" AS "match_null_test_item" WHERE ("tag" IS NOT DISTINCT FROM NULL)`))
+			})
+		})
+
+		When("using an empty array of values", func() {
+			It("should unmarshal json successfully", func() {
+				for _, v := range []string{`[]`, `{"values":[]}`} {
+					f := pgquery.NewMatch("")
+
+					err := json.Unmarshal([]byte(v), f)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(f).To(Equal(pgquery.NewMatch("").Matches()), v)
+				}
+			})
+		})
+	})
+
+	Context("decoding query string", func() {
+		It("should decode and encode null values", func() {
+			req := struct {
+				Tag *pgquery.Match
+			}{}
+
+			values, err := url.ParseQuery("tag=null,a")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(req.Tag.Values).To(Equal([]interface{}{nil, "a"}))
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded).To(Equal(values))
+		})
+
+		When("parameter is blank", func() {
+			It("should not set the filter", func() {
+				req := struct {
+					Status *pgquery.Match
+				}{}
+
+				values, err := url.ParseQuery("status=&q=")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pgquery.DecodeValues(values, &req)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(req.Status).To(BeNil())
+			})
+		})
+
+		When("parameter is empty", func() {
+			It("should decode and encode an empty value list", func() {
+				req := struct {
+					Status *pgquery.Match `pgquery:"empty=error"`
+				}{}
+
+				values, err := url.ParseQuery("status=")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pgquery.DecodeValues(values, &req)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(req.Status).To(Equal(pgquery.NewMatch("").Matches()))
+
+				encoded, err := pgquery.EncodeValues(&req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(encoded).To(Equal(values))
+
+				q := orm.NewQuery(nil, &MatchTestItem{})
+				_, err = pgquery.Bind(q, &req)
+				Expect(errors.Is(err, pgquery.ErrEmptyMatch)).To(BeTrue())
+			})
+		})
+
+		When("value is the null token", func() {
+			It("should return error when encoding", func() {
+				_, err := pgquery.EncodeValues(&struct {
+					Tag *pgquery.Match
+				}{pgquery.NewMatch("").Matches("null")})
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 
 	Context("generating sql", func() {
+		When("using an empty array of values", func() {
+			It("should match none by default", func() {
+				q := orm.NewQuery(nil, &MatchTestItem{})
+
+				q, err := pgquery.NewMatch("name").Matches().Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "match_test_item"."id", "match_test_item"."name" FROM "match_test_items" AS "match_test_item" WHERE (FALSE)`))
+			})
+
+			It("should match all when negated", func() {
+				q := orm.NewQuery(nil, &MatchTestItem{})
+
+				q, err := pgquery.NewMatch("name").Not().Matches().Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "match_test_item"."id", "match_test_item"."name" FROM "match_test_items" AS "match_test_item" WHERE (TRUE)`))
+			})
+
+			It("should skip the filter", func() {
+				q := orm.NewQuery(nil, &MatchTestItem{})
+
+				q, err := pgquery.NewMatch("name").Empty(pgquery.MatchEmptySkip).Matches().Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "match_test_item"."id", "match_test_item"."name" FROM "match_test_items" AS "match_test_item"`))
+			})
+
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &MatchTestItem{})
+
+				f := pgquery.NewMatch("name").Empty(pgquery.MatchEmptyError)
+				err := json.Unmarshal([]byte(`[]`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = f.Apply(q)
+				Expect(err).To(MatchError(pgquery.ErrEmptyMatch))
+			})
+		})
+
 		It("should generate correct SQL string", func() {
 			q := orm.NewQuery(nil, &MatchTestItem{})
 
@@ -104,21 +268,61 @@ var _ = Describe("Match", func() {
 			s := queryString(q)
 			Expect(s).To(Equal(`SELECT "match_test_item"."id", "match_test_item"."name" FROM "match_test_items" AS "match_test_item" WHERE ("name" = 'match')`))
 		})
+
+		It("should generate correct SQL string with null values", func() {
+			for _, t := range []struct {
+				f     *pgquery.Match
+				where string
+			}{
+				{pgquery.NewMatch("name").Matches(nil), `("name" IS NOT DISTINCT FROM NULL)`},
+				{pgquery.NewMatch("name").Matches(nil, "a"), `("name" IS NULL OR "name" IN ('a'))`},
+				{pgquery.NewMatch("name").Matches("a", nil, "b"), `("name" IS NULL OR "name" IN ('a','b'))`},
+			} {
+				q := orm.NewQuery(nil, &MatchNullTestItem{})
+
+				q, err := t.f.Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "match_null_test_item"."id", "match_null_test_item"."name", "match_null_test_item"."tag" FROM "match_null_test_items" AS "match_null_test_item" WHERE ` + t.where))
+			}
+		})
+
+		It("should generate correct SQL string when negated", func() {
+			for _, t := range []struct {
+				f     *pgquery.Match
+				where string
+			}{
+				{pgquery.NewMatch("name").Not().Matches("a"), `("name" IS DISTINCT FROM 'a')`},
+				{pgquery.NewMatch("name").Not().Matches("a", "b"), `("name" IS NULL OR "name" NOT IN ('a','b'))`},
+				{pgquery.NewMatch("name").Not().Matches(nil), `("name" IS DISTINCT FROM NULL)`},
+				{pgquery.NewMatch("name").Not().Matches(nil, "a"), `("name" NOT IN ('a'))`},
+			} {
+				q := orm.NewQuery(nil, &MatchNullTestItem{})
+
+				q, err := t.f.Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "match_null_test_item"."id", "match_null_test_item"."name", "match_null_test_item"."tag" FROM "match_null_test_items" AS "match_null_test_item" WHERE ` + t.where))
+			}
+		})
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*MatchTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*MatchTestItem)(nil))
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			item := &MatchTestItem{
-				Name: fmt.Sprintf("name-%d", itemCount),
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &MatchTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
-			Expect(err).ToNot(HaveOccurred())
-		}
+		})
 
 		It("works with single value", func() {
 			var items []MatchTestItem
@@ -151,5 +355,91 @@ var _ = Describe("Match", func() {
 				}
 			}
 		})
+
+		When("using null and negated values", func() {
+			BeforeEach(func() {
+				createTempTables((*MatchNullTestItem)(nil))
+
+				for itemCount := 1; itemCount <= 10; itemCount++ {
+					item := &MatchNullTestItem{
+						Name: fmt.Sprintf("name-%d", itemCount),
+					}
+					if itemCount%2 == 1 {
+						item.Tag = "odd"
+					}
+					_, err := db.Model(item).Insert()
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			It("works with null values", func() {
+				var items []MatchNullTestItem
+				q := db.Model(&items)
+
+				q.Where(pgquery.NewMatch("tag").Matches(nil).Appender())
+
+				err := q.Select()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(items).To(HaveLen(5))
+
+				items = nil
+				q = db.Model(&items)
+
+				q.Where(pgquery.NewMatch("tag").Matches(nil, "odd").Appender())
+
+				err = q.Select()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(items).To(HaveLen(10))
+			})
+
+			It("works with negated values", func() {
+				var items []MatchNullTestItem
+				q := db.Model(&items)
+
+				q.Where(pgquery.NewMatch("tag").Not().Matches("odd").Appender())
+
+				err := q.Select()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(items).To(HaveLen(5))
+
+				items = nil
+				q = db.Model(&items)
+
+				q.Where(pgquery.NewMatch("name").Not().Matches("name-1", "name-2").Appender())
+
+				err = q.Select()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(items).To(HaveLen(8))
+			})
+
+			It("works with negated null values", func() {
+				var items []MatchNullTestItem
+				q := db.Model(&items)
+
+				q.Where(pgquery.NewMatch("tag").Not().Matches(nil).Appender())
+
+				err := q.Select()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(items).To(HaveLen(5))
+			})
+		})
+
+		It("works with empty values", func() {
+			var items []MatchTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewMatch("name").Matches().Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(BeEmpty())
+		})
 	})
 })
@@ -5,6 +5,10 @@
 package pgquery
 
 import (
+	"errors"
+	"net/url"
+	"strconv"
+
 	"github.com/go-pg/pg/v10/orm"
 )
 
@@ -32,6 +36,21 @@ func (f *OffsetPagination) Offset(page int, limit int) *OffsetPagination {
 	return f
 }
 
+// Next returns a copy of the pagination filter for the next page.
+func (f *OffsetPagination) Next() *OffsetPagination {
+	f.init()
+	return &OffsetPagination{Page: f.Page + 1, Limit: f.Limit}
+}
+
+// Prev returns a copy of the pagination filter for the previous page, or nil on the first page.
+func (f *OffsetPagination) Prev() *OffsetPagination {
+	f.init()
+	if f.Page <= 1 {
+		return nil
+	}
+	return &OffsetPagination{Page: f.Page - 1, Limit: f.Limit}
+}
+
 // Appender returns parameters for cond group appender.
 func (f *OffsetPagination) Appender() applyFn {
 	f.init()
@@ -43,3 +62,43 @@ func (f *OffsetPagination) Appender() applyFn {
 		return q, nil
 	}
 }
+
+// Apply applies the pagination filter to the query.
+func (f *OffsetPagination) Apply(q *orm.Query) (*orm.Query, error) {
+	return q.Apply(f.Appender()), nil
+}
+
+func (f *OffsetPagination) isZero() bool {
+	return f.Limit == nil
+}
+
+func (f *OffsetPagination) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	page, err := paramInt(values, "page")
+	if err != nil {
+		return false, err
+	}
+	limit, err := paramInt(values, "limit")
+	if err != nil {
+		return false, err
+	}
+	if limit != nil && *limit < 0 {
+		return false, decodeError("limit", errors.New("expected a non-negative integer"))
+	}
+	if page != nil {
+		f.Page = *page
+	}
+	if limit != nil {
+		f.Limit = limit
+	}
+	return page != nil || limit != nil, nil
+}
+
+func (f *OffsetPagination) encodeValues(param string, values url.Values) error {
+	if f.Page > 0 {
+		values.Set("page", strconv.Itoa(f.Page))
+	}
+	if f.Limit != nil {
+		values.Set("limit", strconv.Itoa(*f.Limit))
+	}
+	return nil
+}
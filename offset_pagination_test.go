@@ -55,18 +55,19 @@ var _ = Describe("OffsetPagination", func() {
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*OffsetPaginationTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*OffsetPaginationTestItem)(nil))
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			item := &OffsetPaginationTestItem{
-				Name: fmt.Sprintf("name-%d", itemCount),
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &OffsetPaginationTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
-			Expect(err).ToNot(HaveOccurred())
-		}
+		})
 
 		It("works with page and limit", func() {
 			var items []OffsetPaginationTestItem
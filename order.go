@@ -7,8 +7,11 @@ package pgquery
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 
+	"github.com/go-pg/pg/v10/orm"
 	"github.com/go-pg/pg/v10/types"
 )
 
@@ -28,15 +31,33 @@ func (d OrderDirection) String() string {
 	return [...]string{"ASC", "DESC"}[d]
 }
 
+// OrderNulls order NULLs position enum type.
+type OrderNulls int
+
+const (
+	// OrderNullsFirst order NULLs before non-NULL values enum.
+	OrderNullsFirst OrderNulls = iota
+
+	// OrderNullsLast order NULLs after non-NULL values enum.
+	OrderNullsLast
+)
+
+// String returns the string presentation for the order NULLs position.
+func (n OrderNulls) String() string {
+	return [...]string{"NULLS FIRST", "NULLS LAST"}[n]
+}
+
 // Order order common sorter.
 type Order struct {
-	column    string
+	column    sqlColumn
+	resolved  sqlColumn
 	Direction *OrderDirection `json:"direction,omitempty"`
+	Nulls     *OrderNulls     `json:"nulls,omitempty"`
 }
 
 // MarshalJSON custom JSON marshaler.
 func (s *Order) MarshalJSON() ([]byte, error) {
-	return json.Marshal(s.Direction.String())
+	return json.Marshal(s.direction())
 }
 
 // UnmarshalJSON custom JSON unmarshaler.
@@ -45,38 +66,30 @@ func (s *Order) UnmarshalJSON(b []byte) error {
 
 	m1 := struct {
 		Direction string `json:"direction,omitempty"`
+		Nulls     string `json:"nulls,omitempty"`
 		*alias
 	}{alias: (*alias)(s)}
 	var m2 string
 	m3 := struct {
-		Direction int `json:"direction,omitempty"`
+		Direction int    `json:"direction,omitempty"`
+		Nulls     string `json:"nulls,omitempty"`
 		*alias
 	}{alias: (*alias)(s)}
 	var m4 int
 
 	if err := json.Unmarshal(b, &m1); err == nil {
-		d := strings.ToLower(m1.Direction)
-		if d == strings.ToLower(OrderDirectionAsc.String()) {
-			*s.Direction = OrderDirectionAsc
-		}
-		if d == strings.ToLower(OrderDirectionDesc.String()) {
-			*s.Direction = OrderDirectionDesc
-		}
+		s.parseDirection(m1.Direction)
+		s.parseNulls(m1.Nulls)
 		return nil
 	}
 
 	if err := json.Unmarshal(b, &m2); err == nil {
-		d := strings.ToLower(m2)
-		if d == strings.ToLower(OrderDirectionAsc.String()) {
-			*s.Direction = OrderDirectionAsc
-		}
-		if d == strings.ToLower(OrderDirectionDesc.String()) {
-			*s.Direction = OrderDirectionDesc
-		}
+		s.parseDirection(m2)
 		return nil
 	}
 
 	if err := json.Unmarshal(b, &m3); err == nil {
+		s.parseNulls(m3.Nulls)
 		d := OrderDirection(m3.Direction)
 		if d == OrderDirectionAsc {
 			*s.Direction = OrderDirectionAsc
@@ -104,7 +117,7 @@ func (s *Order) UnmarshalJSON(b []byte) error {
 // NewOrder initializes a new order sorter.
 func NewOrder(column string) *Order {
 	return &Order{
-		column:    column,
+		column:    sqlColumn{name: column},
 		Direction: new(OrderDirection),
 	}
 }
@@ -123,7 +136,8 @@ func NewOrderDesc(column string) *Order {
 
 // Column sets the column for the order sorter.
 func (s *Order) Column(column string) *Order {
-	s.column = column
+	s.column = sqlColumn{name: column}
+	s.resolved = sqlColumn{}
 	return s
 }
 
@@ -139,7 +153,181 @@ func (s *Order) Desc() *Order {
 	return s
 }
 
+// NullsFirst sets NULLs to be ordered before non-NULL values.
+func (s *Order) NullsFirst() *Order {
+	nulls := OrderNullsFirst
+	s.Nulls = &nulls
+	return s
+}
+
+// NullsLast sets NULLs to be ordered after non-NULL values.
+func (s *Order) NullsLast() *Order {
+	nulls := OrderNullsLast
+	s.Nulls = &nulls
+	return s
+}
+
+// parseDirection parses "asc" or "desc", optionally followed by "nulls first" or "nulls last".
+func (s *Order) parseDirection(v string) {
+	fields := strings.Fields(strings.ToLower(v))
+	if len(fields) <= 0 {
+		return
+	}
+	if fields[0] == strings.ToLower(OrderDirectionAsc.String()) {
+		*s.Direction = OrderDirectionAsc
+	}
+	if fields[0] == strings.ToLower(OrderDirectionDesc.String()) {
+		*s.Direction = OrderDirectionDesc
+	}
+	if len(fields) == 3 && fields[1] == "nulls" {
+		s.parseNulls(fields[2])
+	}
+}
+
+// parseNulls parses "first" or "last", optionally prefixed with "nulls".
+func (s *Order) parseNulls(v string) {
+	switch strings.TrimPrefix(strings.Join(strings.Fields(strings.ToLower(v)), " "), "nulls ") {
+	case "first":
+		s.NullsFirst()
+	case "last":
+		s.NullsLast()
+	}
+}
+
+// direction returns the direction, followed by the NULLs position when set, e.g. "DESC NULLS LAST".
+func (s *Order) direction() string {
+	if s.Nulls == nil {
+		return s.Direction.String()
+	}
+	return s.Direction.String() + " " + s.Nulls.String()
+}
+
+// reversed returns a copy of the order sorter in the opposite direction.
+func (s *Order) reversed() *Order {
+	o := NewOrder("")
+	o.column, o.resolved = s.column, s.resolved
+	if *s.Direction == OrderDirectionAsc {
+		o.Desc()
+	} else {
+		o.Asc()
+	}
+	if s.Nulls != nil {
+		if *s.Nulls == OrderNullsFirst {
+			o.NullsLast()
+		} else {
+			o.NullsFirst()
+		}
+	}
+	return o
+}
+
+// nullsFirst reports whether NULLs are sorted before non-NULL values, Postgres sorts NULLs as if
+// larger than any non-NULL value unless the position is set.
+func (s *Order) nullsFirst() bool {
+	if s.Nulls != nil {
+		return *s.Nulls == OrderNullsFirst
+	}
+	return *s.Direction == OrderDirectionDesc
+}
+
+// sqlColumn returns the SQL column resolved through a schema, falls back to the column.
+func (s *Order) sqlColumn() sqlColumn {
+	if s.resolved.name != "" {
+		return s.resolved
+	}
+	return s.column
+}
+
 // Appender returns parameters for cond appender.
 func (s *Order) Appender() (string, interface{}, interface{}) {
-	return "? ?", types.Ident(s.column), types.Safe(s.Direction.String())
+	return "? ?", buildIdent(s.sqlColumn()), types.Safe(s.direction())
+}
+
+// Apply applies the order sorter to the query.
+func (s *Order) Apply(q *orm.Query) (*orm.Query, error) {
+	return q.OrderExpr(s.Appender()), nil
+}
+
+func (s *Order) isZero() bool {
+	return s.Direction == nil
+}
+
+func (s *Order) bind(opts *tagOptions) error {
+	if err := opts.allow(); err != nil {
+		return err
+	}
+	s.column = opts.columnFor(s.column)
+	return nil
+}
+
+// parse parses "-column" (descending), "column" or "+column" (ascending), optionally followed by
+// ":nulls_first" or ":nulls_last". A bare "asc" or "desc" only sets the direction when the column
+// is already set.
+func (s *Order) parse(v string) error {
+	if s.Direction == nil {
+		s.Direction = new(OrderDirection)
+	}
+	if i := strings.IndexByte(v, ':'); i >= 0 {
+		switch nulls := strings.ToLower(v[i+1:]); nulls {
+		case "nulls_first":
+			s.NullsFirst()
+		case "nulls_last":
+			s.NullsLast()
+		default:
+			return fmt.Errorf("invalid sort nulls %q", nulls)
+		}
+		v = v[:i]
+	}
+	switch d := strings.ToLower(v); {
+	case s.column.name != "" && d == strings.ToLower(OrderDirectionAsc.String()):
+		s.Asc()
+		return nil
+	case s.column.name != "" && d == strings.ToLower(OrderDirectionDesc.String()):
+		s.Desc()
+		return nil
+	}
+	column := strings.TrimLeft(v, "+-")
+	if !identRegexp.MatchString(column) {
+		return fmt.Errorf("invalid sort column %q", column)
+	}
+	s.column = sqlColumn{name: column}
+	if strings.HasPrefix(v, "-") {
+		s.Desc()
+	} else {
+		s.Asc()
+	}
+	return nil
+}
+
+func (s *Order) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	list := paramList(values, param)
+	switch len(list) {
+	case 0:
+		return false, nil
+	case 1:
+		if err := s.parse(list[0]); err != nil {
+			return false, decodeError(param, err)
+		}
+		return true, nil
+	default:
+		return false, decodeError(param, errors.New("multiple sort keys are not supported"))
+	}
+}
+
+// format formats the order as "-column" (descending) or "column" (ascending), followed by
+// ":nulls_first" or ":nulls_last" when the NULLs position is set.
+func (s *Order) format() string {
+	v := s.column.name
+	if *s.Direction == OrderDirectionDesc {
+		v = "-" + v
+	}
+	if s.Nulls != nil {
+		v += ":" + strings.ReplaceAll(strings.ToLower(s.Nulls.String()), " ", "_")
+	}
+	return v
+}
+
+func (s *Order) encodeValues(param string, values url.Values) error {
+	values.Set(param, s.format())
+	return nil
 }
@@ -32,6 +32,17 @@ var _ = Describe("Order", func() {
 
 			Expect(b).To(MatchJSON(`"ASC"`))
 		})
+
+		When("nulls position is set", func() {
+			It("should marshal json successfully", func() {
+				s := pgquery.NewOrderDesc("").NullsLast()
+
+				b, err := json.Marshal(s)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b).To(MatchJSON(`"DESC NULLS LAST"`))
+			})
+		})
 	})
 
 	Context("unmarshalling json", func() {
@@ -79,6 +90,17 @@ var _ = Describe("Order", func() {
 					Expect(s).To(Equal(pgquery.NewOrderDesc("")))
 				})
 			})
+
+			When("using nulls", func() {
+				It("should unmarshal json successfully", func() {
+					s := pgquery.NewOrderAsc("")
+
+					err := json.Unmarshal([]byte(`{"direction":"desc","nulls":"first"}`), s)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(s).To(Equal(pgquery.NewOrderDesc("").NullsFirst()))
+				})
+			})
 		})
 
 		When("using non-object syntax", func() {
@@ -114,6 +136,17 @@ var _ = Describe("Order", func() {
 					Expect(s).To(Equal(pgquery.NewOrderDesc("")))
 				})
 			})
+
+			When("using nulls", func() {
+				It("should unmarshal json successfully", func() {
+					s := pgquery.NewOrderAsc("")
+
+					err := json.Unmarshal([]byte(`"asc nulls first"`), s)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(s).To(Equal(pgquery.NewOrderAsc("").NullsFirst()))
+				})
+			})
 		})
 	})
 
@@ -126,22 +159,46 @@ var _ = Describe("Order", func() {
 			s := queryString(q)
 			Expect(s).To(Equal(`SELECT "order_test_item"."id", "order_test_item"."name", "order_test_item"."age" FROM "order_test_items" AS "order_test_item" ORDER BY "age" ASC`))
 		})
+
+		When("nulls position is set", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &OrderTestItem{})
+
+				q.OrderExpr(pgquery.NewOrderDesc("age").NullsLast().Appender())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "order_test_item"."id", "order_test_item"."name", "order_test_item"."age" FROM "order_test_items" AS "order_test_item" ORDER BY "age" DESC NULLS LAST`))
+			})
+		})
+
+		When("column is wrapped in parentheses", func() {
+			It("should quote the column", func() {
+				q := orm.NewQuery(nil, &OrderTestItem{})
+
+				q, err := pgquery.NewOrder("(select pg_sleep(10))").Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "order_test_item"."id", "order_test_item"."name", "order_test_item"."age" FROM "order_test_items" AS "order_test_item" ORDER BY "(select pg_sleep(10))" ASC`))
+			})
+		})
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*OrderTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			item := &OrderTestItem{
-				Name: fmt.Sprintf("name-%d", itemCount),
-				Age:  itemCount,
+			createTempTables((*OrderTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &OrderTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+					Age:  itemCount,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
-			Expect(err).ToNot(HaveOccurred())
-		}
+		})
 
 		It("works with asc value", func() {
 			var items []OrderTestItem
@@ -0,0 +1,98 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// PageResult paginated result envelope.
+type PageResult struct {
+	Items      interface{} `json:"items"`
+	Total      int         `json:"total"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	TotalPages int         `json:"totalPages"`
+	HasNext    bool        `json:"hasNext"`
+	HasPrev    bool        `json:"hasPrev"`
+}
+
+func newPageResult(items interface{}, total int, pagination *OffsetPagination) *PageResult {
+	r := &PageResult{
+		Items: items,
+		Total: total,
+		Page:  pagination.Page,
+	}
+	switch {
+	case pagination.Limit == nil:
+		r.Limit = total
+		if total > 0 {
+			r.TotalPages = 1
+		}
+	case *pagination.Limit > 0:
+		r.Limit = *pagination.Limit
+		r.TotalPages = (total + r.Limit - 1) / r.Limit
+	}
+	r.HasNext = r.Page < r.TotalPages
+	r.HasPrev = r.Page > 1
+	return r
+}
+
+// Paginate applies the pagination filter to the query, selects the page into the query model and
+// counts the total number of rows. The query model must be a pointer to a slice, e.g.
+// db.Model(&items). The select and the count run concurrently, queries of a transaction share a
+// single connection and are executed one after another by go-pg. The count is skipped when no
+// limit is provided, as every row is selected.
+func Paginate(ctx context.Context, q *orm.Query, pagination *OffsetPagination) (*PageResult, error) {
+	if pagination == nil {
+		pagination = NewOffsetPagination()
+	}
+	model := q.TableModel()
+	if model == nil || model.Kind() != reflect.Slice {
+		return nil, errors.New("[Paginate]: expected a pointer to slice model")
+	}
+
+	q = q.Context(ctx)
+	count := q.Clone()
+	q, err := pagination.Apply(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int
+	if pagination.Limit == nil {
+		if err := q.Select(); err != nil {
+			return nil, err
+		}
+		total = model.Value().Len()
+	} else {
+		var wg sync.WaitGroup
+		var selectErr, countErr error
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			selectErr = q.Select()
+		}()
+		total, countErr = count.Count()
+		wg.Wait()
+		if selectErr != nil {
+			return nil, selectErr
+		}
+		if countErr != nil {
+			return nil, countErr
+		}
+	}
+
+	items := model.Value()
+	if items.IsNil() {
+		items = reflect.MakeSlice(items.Type(), 0, 0)
+	}
+	return newPageResult(items.Interface(), total, pagination), nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Paginate", func() {
+
+	type PaginateTestItem struct {
+		Id   int64
+		Name string
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			r := &pgquery.PageResult{
+				Items:      []PaginateTestItem{},
+				Total:      10,
+				Page:       2,
+				Limit:      3,
+				TotalPages: 4,
+				HasNext:    true,
+				HasPrev:    true,
+			}
+
+			b, err := json.Marshal(r)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(b)).To(Equal(`{"items":[],"total":10,"page":2,"limit":3,"totalPages":4,"hasNext":true,"hasPrev":true}`))
+		})
+	})
+
+	Context("paginating", func() {
+		It("should reject non-slice model", func() {
+			q := orm.NewQuery(nil, &PaginateTestItem{})
+
+			_, err := pgquery.Paginate(context.Background(), q, pgquery.NewOffsetPagination().Offset(1, 3))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*PaginateTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &PaginateTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with middle page", func() {
+			var items []PaginateTestItem
+			q := db.Model(&items).Order("id")
+
+			r, err := pgquery.Paginate(context.Background(), q, pgquery.NewOffsetPagination().Offset(2, 3))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(r.Items).To(Equal(items))
+			if Expect(items).To(HaveLen(3)) {
+				Expect(items[0].Name).To(Equal("name-4"))
+			}
+			Expect(r.Total).To(Equal(10))
+			Expect(r.Page).To(Equal(2))
+			Expect(r.Limit).To(Equal(3))
+			Expect(r.TotalPages).To(Equal(4))
+			Expect(r.HasNext).To(BeTrue())
+			Expect(r.HasPrev).To(BeTrue())
+		})
+
+		It("works with page out of range", func() {
+			var items []PaginateTestItem
+			q := db.Model(&items).Order("id")
+
+			r, err := pgquery.Paginate(context.Background(), q, pgquery.NewOffsetPagination().Offset(5, 3))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(r.Items).To(Equal([]PaginateTestItem{}))
+			Expect(r.Total).To(Equal(10))
+			Expect(r.HasNext).To(BeFalse())
+			Expect(r.HasPrev).To(BeTrue())
+		})
+
+		It("works without limit", func() {
+			var items []PaginateTestItem
+			q := db.Model(&items).Where("id > ?", 5)
+
+			r, err := pgquery.Paginate(context.Background(), q, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(5))
+			Expect(r.Total).To(Equal(5))
+			Expect(r.Page).To(Equal(1))
+			Expect(r.TotalPages).To(Equal(1))
+			Expect(r.HasNext).To(BeFalse())
+			Expect(r.HasPrev).To(BeFalse())
+		})
+	})
+})
@@ -5,7 +5,76 @@
 package pgquery
 
 import (
+	"reflect"
+	"time"
+
 	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
 )
 
 type applyFn = func(q *orm.Query) (*orm.Query, error)
+
+// sqlColumn filter column. The name is always quoted as an identifier, unless the column was
+// resolved from a raw SQL expression registered with Schema.Expr.
+type sqlColumn struct {
+	name string
+	expr bool
+}
+
+// buildIdent returns the column identifier, or the raw SQL expression of a Schema.Expr column.
+func buildIdent(column sqlColumn) interface{} {
+	if column.expr {
+		return types.Safe(column.name)
+	}
+	return types.Ident(column.name)
+}
+
+// Clock provides the current time to filters relative to now, resolved each time the filter is
+// applied rather than when it is constructed.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapter to use an ordinary function as a Clock, e.g. ClockFunc(time.Now).
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// Filter common interface implemented by all filters and sorters.
+type Filter interface {
+	// Apply applies the filter to the query.
+	Apply(q *orm.Query) (*orm.Query, error)
+}
+
+// isNil reports whether the filter is nil, including a nil pointer held by the interface, e.g. an
+// unset optional request struct field.
+func isNil(f Filter) bool {
+	if f == nil {
+		return true
+	}
+	v := reflect.ValueOf(f)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// ApplyAll applies filter(s) to the query in order. Nil filters, including nil pointers, are skipped.
+func ApplyAll(q *orm.Query, filters ...Filter) (*orm.Query, error) {
+	for _, f := range filters {
+		if isNil(f) {
+			continue
+		}
+		var err error
+		q, err = f.Apply(q)
+		if err != nil {
+			return q, err
+		}
+	}
+	return q, nil
+}
@@ -13,12 +13,14 @@ import (
 	"github.com/go-pg/pg/v10"
 	"github.com/go-pg/pg/v10/orm"
 	"github.com/go-pg/pgext"
+	"github.com/junwen-k/pgquery"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
 var (
 	db       *pg.DB
+	dbErr    error
 	testTime time.Time
 )
 
@@ -42,6 +44,51 @@ func TestFilter(t *testing.T) {
 	RunSpecs(t, "Filter Suite")
 }
 
+var _ = BeforeSuite(func() {
+	_, dbErr = db.Exec("SELECT 1")
+})
+
+// requireDB skips the current spec when the test database is not reachable.
+func requireDB() {
+	if dbErr != nil {
+		Skip("database is not available: " + dbErr.Error())
+	}
+}
+
+// requireExtension skips the current spec when the given extension cannot be
+// enabled on the test database.
+func requireExtension(name string) {
+	requireDB()
+	if _, err := db.Exec("CREATE EXTENSION IF NOT EXISTS ?", pg.Ident(name)); err != nil {
+		Skip("extension " + name + " is not available: " + err.Error())
+	}
+}
+
+// requireJSONPath skips the current spec when the test database does not
+// support the jsonpath type (PostgreSQL 12+).
+func requireJSONPath() {
+	requireDB()
+	if _, err := db.Exec("SELECT '$'::jsonpath"); err != nil {
+		Skip("jsonpath is not available: " + err.Error())
+	}
+}
+
+// createTempTables (re)creates temporary tables for the given models so that
+// every spec starts from an empty table.
+func createTempTables(models ...interface{}) {
+	for _, model := range models {
+		err := db.Model(model).DropTable(&orm.DropTableOptions{
+			IfExists: true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = db.Model(model).CreateTable(&orm.CreateTableOptions{
+			Temp: true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+}
+
 func selectQueryString(q *orm.Query) string {
 	sel := orm.NewSelectQuery(q)
 	s := queryString(sel)
@@ -54,3 +101,42 @@ func queryString(f orm.QueryAppender) string {
 	Expect(err).NotTo(HaveOccurred())
 	return string(b)
 }
+
+var _ = Describe("ApplyAll", func() {
+
+	type ApplyAllTestItem struct {
+		Id   int64
+		Name string
+		Age  int
+	}
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &ApplyAllTestItem{})
+
+			q, err := pgquery.ApplyAll(q,
+				pgquery.NewMatch("name").Matches("match"),
+				pgquery.NewRange("age").GreaterThan(0),
+				pgquery.NewOrderDesc("id"),
+				pgquery.NewOffsetPagination().Offset(2, 10),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "apply_all_test_item"."id", "apply_all_test_item"."name", "apply_all_test_item"."age" FROM "apply_all_test_items" AS "apply_all_test_item" WHERE ("name" = 'match') AND (("age" > 0)) ORDER BY "id" DESC LIMIT 10 OFFSET 10`))
+		})
+
+		It("should skip nil filters", func() {
+			q := orm.NewQuery(nil, &ApplyAllTestItem{})
+
+			var m *pgquery.Match
+			var r *pgquery.Range
+
+			q, err := pgquery.ApplyAll(q, nil, m, pgquery.NewMatch("name").Matches("match"), r)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "apply_all_test_item"."id", "apply_all_test_item"."name", "apply_all_test_item"."age" FROM "apply_all_test_items" AS "apply_all_test_item" WHERE ("name" = 'match')`))
+		})
+	})
+})
@@ -5,13 +5,15 @@
 package pgquery
 
 import (
+	"net/url"
+	"strconv"
+
 	"github.com/go-pg/pg/v10/orm"
-	"github.com/go-pg/pg/v10/types"
 )
 
 // Range range common filter.
 type Range struct {
-	column string
+	column sqlColumn
 	Gt     *int `json:"gt,omitempty"`
 	Gte    *int `json:"gte,omitempty"`
 	Lt     *int `json:"lt,omitempty"`
@@ -21,13 +23,13 @@ type Range struct {
 // NewRange initializes a new range filter.
 func NewRange(column string) *Range {
 	return &Range{
-		column: column,
+		column: sqlColumn{name: column},
 	}
 }
 
 // Column set the column for the range filter.
 func (f *Range) Column(column string) *Range {
-	f.column = column
+	f.column = sqlColumn{name: column}
 	return f
 }
 
@@ -59,17 +61,76 @@ func (f *Range) LessThanEqual(value int) *Range {
 func (f *Range) Appender() applyFn {
 	return func(q *orm.Query) (*orm.Query, error) {
 		if f.Lt != nil {
-			q.Where("? < ?", types.Ident(f.column), f.Lt)
+			q.Where("? < ?", buildIdent(f.column), f.Lt)
 		}
 		if f.Lte != nil {
-			q.Where("? <= ?", types.Ident(f.column), f.Lte)
+			q.Where("? <= ?", buildIdent(f.column), f.Lte)
 		}
 		if f.Gte != nil {
-			q.Where("? >= ?", types.Ident(f.column), f.Gte)
+			q.Where("? >= ?", buildIdent(f.column), f.Gte)
 		}
 		if f.Gt != nil {
-			q.Where("? > ?", types.Ident(f.column), f.Gt)
+			q.Where("? > ?", buildIdent(f.column), f.Gt)
 		}
 		return q, nil
 	}
 }
+
+// Apply applies the range filter to the query.
+func (f *Range) Apply(q *orm.Query) (*orm.Query, error) {
+	return q.WhereGroup(f.Appender()), nil
+}
+
+func (f *Range) isZero() bool {
+	return f.Gt == nil && f.Gte == nil && f.Lt == nil && f.Lte == nil
+}
+
+func (f *Range) bind(opts *tagOptions) error {
+	if err := opts.allow(); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	return nil
+}
+
+func (f *Range) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	bounds := []struct {
+		key   string
+		value **int
+	}{
+		{"gt", &f.Gt},
+		{"gte", &f.Gte},
+		{"lt", &f.Lt},
+		{"lte", &f.Lte},
+	}
+	found := false
+	for _, bound := range bounds {
+		v, err := paramInt(values, param+"["+bound.key+"]")
+		if err != nil {
+			return false, err
+		}
+		if v != nil {
+			*bound.value = v
+			found = true
+		}
+	}
+	return found, nil
+}
+
+func (f *Range) encodeValues(param string, values url.Values) error {
+	bounds := []struct {
+		key   string
+		value *int
+	}{
+		{"gt", f.Gt},
+		{"gte", f.Gte},
+		{"lt", f.Lt},
+		{"lte", f.Lte},
+	}
+	for _, bound := range bounds {
+		if bound.value != nil {
+			values.Set(param+"["+bound.key+"]", strconv.Itoa(*bound.value))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
+)
+
+// rangeTypes range types mapped to their element type.
+var rangeTypes = map[string]string{
+	"int4range": "integer",
+	"int8range": "bigint",
+	"numrange":  "numeric",
+	"tsrange":   "timestamp",
+	"tstzrange": "timestamptz",
+	"daterange": "date",
+}
+
+// RangeBounds range value in the "[lower,upper)" notation, or a single point. A nil bound is unbounded.
+type RangeBounds struct {
+	Lower          interface{}
+	Upper          interface{}
+	LowerInclusive bool
+	UpperInclusive bool
+	point          bool
+}
+
+// UnmarshalJSON custom JSON unmarshaler, e.g. "[2020-01-01,2020-02-01)", or a point, e.g. 5.
+func (r *RangeBounds) UnmarshalJSON(b []byte) error {
+	var m1 string
+	var m2 interface{}
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		parsed, err := ParseRangeBounds(m1)
+		if err != nil {
+			return err
+		}
+		*r = *parsed
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		*r = *NewRangePoint(m2)
+		return nil
+	}
+
+	return errors.New("[RangeBounds]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (r *RangeBounds) MarshalJSON() ([]byte, error) {
+	if r.point {
+		if t, ok := r.Lower.(time.Time); ok {
+			return json.Marshal(t.Format(time.RFC3339Nano))
+		}
+		return json.Marshal(r.Lower)
+	}
+	return json.Marshal(r.String())
+}
+
+// NewRangeBounds initializes a new range value, bounds is one of "[)", "[]", "(]" or "()".
+func NewRangeBounds(lower, upper interface{}, bounds string) *RangeBounds {
+	return &RangeBounds{
+		Lower:          lower,
+		Upper:          upper,
+		LowerInclusive: strings.HasPrefix(bounds, "["),
+		UpperInclusive: strings.HasSuffix(bounds, "]"),
+	}
+}
+
+// NewRangePoint initializes a new range point value.
+func NewRangePoint(v interface{}) *RangeBounds {
+	return &RangeBounds{
+		Lower: v,
+		Upper: v,
+		point: true,
+	}
+}
+
+// ParseRangeBounds parses the "[lower,upper)" notation, bounds may be empty (unbounded) or double
+// quoted. A value without brackets is parsed as a point.
+func ParseRangeBounds(s string) (*RangeBounds, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("[RangeBounds]: empty value")
+	}
+	if !strings.ContainsAny(s[:1], "[(") && !strings.ContainsAny(s[len(s)-1:], "])") {
+		return NewRangePoint(s), nil
+	}
+	if len(s) < 3 || !strings.ContainsAny(s[:1], "[(") || !strings.ContainsAny(s[len(s)-1:], "])") {
+		return nil, fmt.Errorf("[RangeBounds]: malformed range %q", s)
+	}
+	bounds, err := splitRangeBounds(s[1 : len(s)-1])
+	if err != nil {
+		return nil, fmt.Errorf("[RangeBounds]: malformed range %q: %v", s, err)
+	}
+	return NewRangeBounds(bounds[0], bounds[1], s[:1]+s[len(s)-1:]), nil
+}
+
+// splitRangeBounds splits the lower and upper bound on the comma, the same way as Postgres parses
+// range literals. Unquoted empty bounds are returned as nil.
+func splitRangeBounds(s string) ([2]interface{}, error) {
+	var bounds [2]interface{}
+	var b strings.Builder
+	index, quoted, inQuote := 0, false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case c == '"':
+			inQuote = !inQuote
+			quoted = true
+		case c == ',' && !inQuote:
+			if index > 0 {
+				return bounds, errors.New("too many bounds")
+			}
+			if b.Len() > 0 || quoted {
+				bounds[index] = b.String()
+			}
+			b.Reset()
+			index, quoted = index+1, false
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if inQuote {
+		return bounds, errors.New("unterminated quote")
+	}
+	if index != 1 {
+		return bounds, errors.New("expected lower and upper bounds")
+	}
+	if b.Len() > 0 || quoted {
+		bounds[index] = b.String()
+	}
+	return bounds, nil
+}
+
+func formatRangeBound(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func quoteRangeBound(v interface{}) string {
+	s := formatRangeBound(v)
+	if v == nil || (s != "" && !strings.ContainsAny(s, `,()[]"\ `)) {
+		return s
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// String returns the "[lower,upper)" notation for the range value.
+func (r *RangeBounds) String() string {
+	if r.point {
+		return formatRangeBound(r.Lower)
+	}
+	lb, ub := "(", ")"
+	if r.LowerInclusive {
+		lb = "["
+	}
+	if r.UpperInclusive {
+		ub = "]"
+	}
+	return lb + quoteRangeBound(r.Lower) + "," + quoteRangeBound(r.Upper) + ub
+}
+
+// IsPoint reports whether the range value is a single point.
+func (r *RangeBounds) IsPoint() bool {
+	return r.point
+}
+
+// RangeColumn range type (int4range, tstzrange, daterange etc.) column common filter.
+type RangeColumn struct {
+	column    sqlColumn
+	rangeType string
+	Overlap   *RangeBounds `json:"overlaps,omitempty"`
+	Contain   *RangeBounds `json:"contains,omitempty"`
+	Within    *RangeBounds `json:"containedBy,omitempty"`
+	Adjacent  *RangeBounds `json:"adjacent,omitempty"`
+	Left      *RangeBounds `json:"leftOf,omitempty"`
+	Right     *RangeBounds `json:"rightOf,omitempty"`
+}
+
+// NewRangeColumn initializes a new range column filter.
+func NewRangeColumn(column string, rangeType string) *RangeColumn {
+	return &RangeColumn{
+		column:    sqlColumn{name: column},
+		rangeType: rangeType,
+	}
+}
+
+// Column set the column for the range column filter.
+func (f *RangeColumn) Column(column string) *RangeColumn {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Type set the range type for the range column filter, e.g. tstzrange, daterange, int4range.
+func (f *RangeColumn) Type(rangeType string) *RangeColumn {
+	f.rangeType = rangeType
+	return f
+}
+
+// Overlaps set value for overlaps (&&).
+func (f *RangeColumn) Overlaps(value *RangeBounds) *RangeColumn {
+	f.Overlap = value
+	return f
+}
+
+// Contains set value for contains (@>), the value may be a range or a point.
+func (f *RangeColumn) Contains(value *RangeBounds) *RangeColumn {
+	f.Contain = value
+	return f
+}
+
+// ContainsPoint set point value for contains (@>).
+func (f *RangeColumn) ContainsPoint(value interface{}) *RangeColumn {
+	return f.Contains(NewRangePoint(value))
+}
+
+// ContainedBy set value for contained by (<@).
+func (f *RangeColumn) ContainedBy(value *RangeBounds) *RangeColumn {
+	f.Within = value
+	return f
+}
+
+// AdjacentTo set value for adjacent to (-|-).
+func (f *RangeColumn) AdjacentTo(value *RangeBounds) *RangeColumn {
+	f.Adjacent = value
+	return f
+}
+
+// LeftOf set value for strictly left of (<<).
+func (f *RangeColumn) LeftOf(value *RangeBounds) *RangeColumn {
+	f.Left = value
+	return f
+}
+
+// RightOf set value for strictly right of (>>).
+func (f *RangeColumn) RightOf(value *RangeBounds) *RangeColumn {
+	f.Right = value
+	return f
+}
+
+func (f *RangeColumn) buildValue(value *RangeBounds) interface{} {
+	if value.point {
+		return orm.SafeQuery("?::?", value.Lower, types.Safe(rangeTypes[f.rangeType]))
+	}
+	return orm.SafeQuery("?::?", value.String(), types.Safe(f.rangeType))
+}
+
+// Appender returns parameters for cond group appender.
+func (f *RangeColumn) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		if _, ok := rangeTypes[f.rangeType]; !ok {
+			return q, fmt.Errorf("[RangeColumn]: unsupported range type %q", f.rangeType)
+		}
+		operators := []struct {
+			op    string
+			value *RangeBounds
+		}{
+			{"&&", f.Overlap},
+			{"@>", f.Contain},
+			{"<@", f.Within},
+			{"-|-", f.Adjacent},
+			{"<<", f.Left},
+			{">>", f.Right},
+		}
+		for _, o := range operators {
+			if o.value == nil {
+				continue
+			}
+			if o.value.point && o.op != "@>" {
+				return q, fmt.Errorf("[RangeColumn]: %s expects a range, got point %q", o.op, o.value)
+			}
+			q.Where("? "+o.op+" ?", buildIdent(f.column), f.buildValue(o.value))
+		}
+		return q, nil
+	}
+}
+
+// Apply applies the range column filter to the query.
+func (f *RangeColumn) Apply(q *orm.Query) (*orm.Query, error) {
+	var err error
+	q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q, err = f.Appender()(q)
+		return q, err
+	})
+	return q, err
+}
+
+func (f *RangeColumn) isZero() bool {
+	return f.Overlap == nil && f.Contain == nil && f.Within == nil && f.Adjacent == nil && f.Left == nil && f.Right == nil
+}
+
+func (f *RangeColumn) bind(opts *tagOptions) error {
+	if err := opts.allow("type"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("type") {
+		f.Type(opts.get("type"))
+	}
+	return nil
+}
+
+func (f *RangeColumn) bounds() []struct {
+	key   string
+	value **RangeBounds
+} {
+	return []struct {
+		key   string
+		value **RangeBounds
+	}{
+		{"overlaps", &f.Overlap},
+		{"contains", &f.Contain},
+		{"containedBy", &f.Within},
+		{"adjacent", &f.Adjacent},
+		{"leftOf", &f.Left},
+		{"rightOf", &f.Right},
+	}
+}
+
+func (f *RangeColumn) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	found := false
+	for _, bound := range f.bounds() {
+		key := param + "[" + bound.key + "]"
+		v, ok := paramValue(values, key)
+		if !ok {
+			continue
+		}
+		r, err := ParseRangeBounds(v)
+		if err != nil {
+			return false, decodeError(key, err)
+		}
+		*bound.value = r
+		found = true
+	}
+	return found, nil
+}
+
+func (f *RangeColumn) encodeValues(param string, values url.Values) error {
+	for _, bound := range f.bounds() {
+		if *bound.value != nil {
+			values.Set(param+"["+bound.key+"]", (*bound.value).String())
+		}
+	}
+	return nil
+}
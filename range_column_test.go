@@ -0,0 +1,201 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RangeColumn", func() {
+
+	type RangeColumnTestItem struct {
+		Id     int64
+		Period string `pg:"type:daterange"`
+		Seats  string `pg:"type:int4range"`
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			f := pgquery.NewRangeColumn("", "daterange").
+				Overlaps(pgquery.NewRangeBounds("2020-01-01", "2020-02-01", "[)")).
+				ContainsPoint("2020-01-15").
+				LeftOf(pgquery.NewRangeBounds(nil, "2020-03-01", "(]"))
+
+			b, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`{"overlaps":"[2020-01-01,2020-02-01)","contains":"2020-01-15","leftOf":"(,2020-03-01]"}`))
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		It("should unmarshal json successfully", func() {
+			f := pgquery.NewRangeColumn("", "int4range")
+
+			err := json.Unmarshal([]byte(`{"overlaps":"[1,5)","contains":3,"adjacent":"(5,]","rightOf":"[,\"0\"]"}`), f)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(f).To(Equal(pgquery.NewRangeColumn("", "int4range").
+				Overlaps(pgquery.NewRangeBounds("1", "5", "[)")).
+				ContainsPoint(3.0).
+				AdjacentTo(pgquery.NewRangeBounds("5", nil, "(]")).
+				RightOf(pgquery.NewRangeBounds(nil, "0", "[]"))))
+		})
+
+		When("using malformed range", func() {
+			It("should return error", func() {
+				for _, v := range []string{`{"overlaps":"[1,5"}`, `{"overlaps":"[1,2,3)"}`, `{"overlaps":"[\"1,5)"}`} {
+					err := json.Unmarshal([]byte(v), pgquery.NewRangeColumn("", "int4range"))
+					Expect(err).To(HaveOccurred(), v)
+				}
+			})
+		})
+	})
+
+	Context("decoding query string", func() {
+		It("should decode and encode query string values", func() {
+			req := struct {
+				Period *pgquery.RangeColumn `pgquery:"type=daterange"`
+			}{}
+
+			values, err := url.ParseQuery("period[overlaps]=[2020-01-01,2020-02-01)&period[containedBy]=(,2021-01-01]")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded).To(Equal(values))
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &RangeColumnTestItem{})
+
+			q, err := pgquery.NewRangeColumn("period", "daterange").
+				Overlaps(pgquery.NewRangeBounds("2020-01-01", "2020-02-01", "[)")).
+				ContainsPoint("2020-01-15").
+				ContainedBy(pgquery.NewRangeBounds(nil, nil, "()")).
+				AdjacentTo(pgquery.NewRangeBounds("2020-02-01", nil, "[)")).
+				LeftOf(pgquery.NewRangeBounds("2021-01-01", nil, "[)")).
+				RightOf(pgquery.NewRangeBounds(nil, "2019-01-01", "()")).
+				Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "range_column_test_item"."id", "range_column_test_item"."period", "range_column_test_item"."seats" FROM "range_column_test_items" AS "range_column_test_item" WHERE (("period" && '[2020-01-01,2020-02-01)'::daterange) AND ("period" @> '2020-01-15'::date) AND ("period" <@ '(,)'::daterange) AND ("period" -|- '[2020-02-01,)'::daterange) AND ("period" << '[2021-01-01,)'::daterange) AND ("period" >> '(,2019-01-01)'::daterange))`))
+		})
+
+		It("should quote bounds", func() {
+			q := orm.NewQuery(nil, &RangeColumnTestItem{})
+
+			q, err := pgquery.NewRangeColumn("period", "tstzrange").Overlaps(pgquery.NewRangeBounds("2020-01-01 00:00:00+00", `x",y`, "[]")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "range_column_test_item"."id", "range_column_test_item"."period", "range_column_test_item"."seats" FROM "range_column_test_items" AS "range_column_test_item" WHERE (("period" && '["2020-01-01 00:00:00+00","x\",y"]'::tstzrange))`))
+		})
+
+		When("using unsupported range type", func() {
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &RangeColumnTestItem{})
+
+				_, err := pgquery.NewRangeColumn("period", "text").ContainsPoint(1).Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("using point for range operator", func() {
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &RangeColumnTestItem{})
+
+				_, err := pgquery.NewRangeColumn("seats", "int4range").Overlaps(pgquery.NewRangePoint(1)).Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*RangeColumnTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &RangeColumnTestItem{
+					Period: fmt.Sprintf("[2020-01-%02d,2020-01-%02d)", itemCount, itemCount+2),
+					Seats:  fmt.Sprintf("[%d,%d)", itemCount*10, itemCount*10+10),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with overlaps", func() {
+			var items []RangeColumnTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRangeColumn("period", "daterange").Overlaps(pgquery.NewRangeBounds("2020-01-05", "2020-01-06", "[)")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(2))
+		})
+
+		It("works with contains point", func() {
+			var items []RangeColumnTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRangeColumn("seats", "int4range").ContainsPoint(50).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(1)) {
+				Expect(items[0].Id).To(Equal(int64(5)))
+			}
+		})
+
+		It("works with adjacent", func() {
+			var items []RangeColumnTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRangeColumn("seats", "int4range").AdjacentTo(pgquery.NewRangeBounds(0, 10, "[)")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(1)) {
+				Expect(items[0].Id).To(Equal(int64(1)))
+			}
+		})
+
+		It("works with strictly left of", func() {
+			var items []RangeColumnTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRangeColumn("period", "daterange").LeftOf(pgquery.NewRangeBounds("2020-01-05", nil, "[)")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+	})
+})
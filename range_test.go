@@ -55,19 +55,20 @@ var _ = Describe("Range", func() {
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*RangeTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*RangeTestItem)(nil))
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			item := &RangeTestItem{
-				Age:    itemCount,
-				Height: (itemCount * 10) + 130,
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &RangeTestItem{
+					Age:    itemCount,
+					Height: (itemCount * 10) + 130,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
-			Expect(err).ToNot(HaveOccurred())
-		}
+		})
 
 		It("works with greater than filter", func() {
 			var items []RangeTestItem
@@ -0,0 +1,284 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10/types"
+)
+
+// DefaultRegexMaxLength default maximum pattern length for the regex filter.
+const DefaultRegexMaxLength = 256
+
+// regexMaxRepeat maximum repetition count accepted by Postgres, e.g. "a{255}".
+const regexMaxRepeat = 255
+
+// PatternError regex filter pattern is too long or is not a valid pattern.
+type PatternError struct {
+	Pattern string
+	Err     error
+}
+
+// Error returns the error message naming the rejected pattern.
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("[Regex]: invalid pattern %q: %v", e.Pattern, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PatternError) Unwrap() error {
+	return e.Err
+}
+
+// Regex regular expression (~, ~*, !~, !~*) or SIMILAR TO common filter.
+type Regex struct {
+	column          sqlColumn
+	caseInsensitive bool
+	not             bool
+	similar         bool
+	maxLength       int
+	Value           *string `json:"value,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler.
+func (f *Regex) UnmarshalJSON(b []byte) error {
+	m1 := struct {
+		Value           *string `json:"value"`
+		CaseInsensitive bool    `json:"caseInsensitive"`
+	}{}
+	var m2 *string
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Value = m1.Value
+		if m1.CaseInsensitive {
+			f.CaseInsensitive()
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Value = m2
+		return nil
+	}
+
+	return errors.New("[Regex]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *Regex) MarshalJSON() ([]byte, error) {
+	if f.caseInsensitive {
+		return json.Marshal(map[string]interface{}{"value": f.Value, "caseInsensitive": true})
+	}
+	return json.Marshal(f.Value)
+}
+
+// NewRegex initializes a new regex filter.
+func NewRegex(column string) *Regex {
+	return &Regex{
+		column: sqlColumn{name: column},
+	}
+}
+
+// Column set the column for the regex filter.
+func (f *Regex) Column(column string) *Regex {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// CaseInsensitive set case insensitive match (~*) for the regex filter.
+func (f *Regex) CaseInsensitive() *Regex {
+	f.caseInsensitive = true
+	return f
+}
+
+// Not set negated match (!~) for the regex filter.
+func (f *Regex) Not() *Regex {
+	f.not = true
+	return f
+}
+
+// Similar set SQL standard SIMILAR TO match for the regex filter instead of POSIX regular expression.
+func (f *Regex) Similar() *Regex {
+	f.similar = true
+	return f
+}
+
+// MaxLength set the maximum pattern length for the regex filter, defaults to DefaultRegexMaxLength.
+func (f *Regex) MaxLength(maxLength int) *Regex {
+	f.maxLength = maxLength
+	return f
+}
+
+// Pattern set value.
+func (f *Regex) Pattern(pattern string) *Regex {
+	f.Value = &pattern
+	return f
+}
+
+func (f *Regex) buildValue() string {
+	if f.Value != nil {
+		return *f.Value
+	}
+	return ""
+}
+
+func (f *Regex) buildOperator() string {
+	switch {
+	case f.similar && f.not:
+		return "NOT SIMILAR TO"
+	case f.similar:
+		return "SIMILAR TO"
+	case f.caseInsensitive && f.not:
+		return "!~*"
+	case f.caseInsensitive:
+		return "~*"
+	case f.not:
+		return "!~"
+	default:
+		return "~"
+	}
+}
+
+// similarToRegexp translates the SIMILAR TO pattern to the equivalent regular expression, the
+// same way as Postgres does, e.g. "%" matches any string and "_" any character.
+func similarToRegexp(pattern string) string {
+	var b strings.Builder
+	inBracket := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case inBracket:
+			if c == ']' {
+				inBracket = false
+			}
+			b.WriteByte(c)
+		case c == '[':
+			inBracket = true
+			b.WriteByte(c)
+		case c == '%':
+			b.WriteString(".*")
+		case c == '_':
+			b.WriteByte('.')
+		case c == '.' || c == '^' || c == '$':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// Validate returns a PatternError when the pattern exceeds the maximum length, or does not compile
+// as a POSIX extended regular expression (SIMILAR TO patterns are translated first). Features
+// outside the POSIX subset, e.g. back references and Perl classes, and repetition counts above
+// 255 are rejected.
+func (f *Regex) Validate() error {
+	pattern := f.buildValue()
+	maxLength := f.maxLength
+	if maxLength == 0 {
+		maxLength = DefaultRegexMaxLength
+	}
+	if maxLength > 0 && len(pattern) > maxLength {
+		return &PatternError{Pattern: pattern, Err: fmt.Errorf("exceeds maximum length of %d", maxLength)}
+	}
+	expr := pattern
+	if f.similar {
+		expr = similarToRegexp(pattern)
+	}
+	if _, err := regexp.CompilePOSIX(expr); err != nil {
+		return &PatternError{Pattern: pattern, Err: err}
+	}
+	re, err := syntax.Parse(expr, syntax.POSIX)
+	if err != nil {
+		return &PatternError{Pattern: pattern, Err: err}
+	}
+	if err := checkRepeat(re); err != nil {
+		return &PatternError{Pattern: pattern, Err: err}
+	}
+	return nil
+}
+
+// checkRepeat returns an error when a repetition count exceeds the Postgres limit.
+func checkRepeat(re *syntax.Regexp) error {
+	if re.Op == syntax.OpRepeat && (re.Min > regexMaxRepeat || re.Max > regexMaxRepeat) {
+		return fmt.Errorf("repetition count exceeds %d", regexMaxRepeat)
+	}
+	for _, sub := range re.Sub {
+		if err := checkRepeat(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Appender returns parameters for cond appender.
+func (f *Regex) Appender() (string, interface{}, interface{}, interface{}) {
+	return "? ? ?", buildIdent(f.column), types.Safe(f.buildOperator()), f.buildValue()
+}
+
+// Apply applies the regex filter to the query.
+func (f *Regex) Apply(q *orm.Query) (*orm.Query, error) {
+	if f.similar && f.caseInsensitive {
+		return q, errors.New("[Regex]: SIMILAR TO does not support case insensitive match")
+	}
+	if err := f.Validate(); err != nil {
+		return q, err
+	}
+	return q.Where(f.Appender()), nil
+}
+
+func (f *Regex) isZero() bool {
+	return f.Value == nil
+}
+
+func (f *Regex) bind(opts *tagOptions) error {
+	if err := opts.allow("ci", "not", "similar", "max"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("ci") {
+		f.CaseInsensitive()
+	}
+	if opts.has("not") {
+		f.Not()
+	}
+	if opts.has("similar") {
+		f.Similar()
+	}
+	if opts.has("max") {
+		maxLength, err := strconv.Atoi(opts.get("max"))
+		if err != nil {
+			return fmt.Errorf("invalid max %q", opts.get("max"))
+		}
+		f.MaxLength(maxLength)
+	}
+	return nil
+}
+
+func (f *Regex) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	v, ok := paramValue(values, param)
+	if !ok {
+		return false, nil
+	}
+	f.Pattern(v)
+	return true, nil
+}
+
+func (f *Regex) encodeValues(param string, values url.Values) error {
+	values.Set(param, *f.Value)
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Regex", func() {
+
+	type RegexTestItem struct {
+		Id   int64
+		Name string
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			f := pgquery.NewRegex("").Pattern("^name-[0-9]+$")
+
+			b, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`"^name-[0-9]+$"`))
+		})
+
+		When("case insensitive is set", func() {
+			It("should marshal json successfully", func() {
+				f := pgquery.NewRegex("").CaseInsensitive().Pattern("^NAME")
+
+				b, err := json.Marshal(f)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b).To(MatchJSON(`{"value":"^NAME","caseInsensitive":true}`))
+			})
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		When("using object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewRegex("")
+
+				err := json.Unmarshal([]byte(`{"value":"^NAME","caseInsensitive":true}`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewRegex("").CaseInsensitive().Pattern("^NAME")))
+			})
+		})
+
+		When("using non-object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewRegex("")
+
+				err := json.Unmarshal([]byte(`"^name"`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewRegex("").Pattern("^name")))
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			for _, t := range []struct {
+				f     *pgquery.Regex
+				where string
+			}{
+				{pgquery.NewRegex("name").Pattern("^name-1$"), `("name" ~ '^name-1$')`},
+				{pgquery.NewRegex("name").CaseInsensitive().Pattern("^NAME"), `("name" ~* '^NAME')`},
+				{pgquery.NewRegex("name").Not().Pattern("^name"), `("name" !~ '^name')`},
+				{pgquery.NewRegex("name").Not().CaseInsensitive().Pattern("^NAME"), `("name" !~* '^NAME')`},
+				{pgquery.NewRegex("name").Similar().Pattern("name-(1|2)%"), `("name" SIMILAR TO 'name-(1|2)%')`},
+				{pgquery.NewRegex("name").Similar().Not().Pattern("name-_"), `("name" NOT SIMILAR TO 'name-_')`},
+			} {
+				q := orm.NewQuery(nil, &RegexTestItem{})
+
+				q, err := t.f.Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "regex_test_item"."id", "regex_test_item"."name" FROM "regex_test_items" AS "regex_test_item" WHERE ` + t.where))
+			}
+		})
+
+		When("using invalid pattern", func() {
+			It("should return pattern error", func() {
+				for _, f := range []*pgquery.Regex{
+					pgquery.NewRegex("name").Pattern("(name"),
+					pgquery.NewRegex("name").Pattern(`(a)\1`),
+					pgquery.NewRegex("name").Pattern(`\d+`),
+					pgquery.NewRegex("name").Pattern("a{1001}"),
+					pgquery.NewRegex("name").Pattern("a{300}"),
+					pgquery.NewRegex("name").Pattern("(ab){1,256}"),
+					pgquery.NewRegex("name").Similar().Pattern("a{256,}"),
+					pgquery.NewRegex("name").Pattern(strings.Repeat("a", pgquery.DefaultRegexMaxLength+1)),
+					pgquery.NewRegex("name").MaxLength(4).Pattern("names"),
+					pgquery.NewRegex("name").Similar().Pattern("name-(1|2"),
+				} {
+					q := orm.NewQuery(nil, &RegexTestItem{})
+
+					_, err := f.Apply(q)
+
+					var patternErr *pgquery.PatternError
+					Expect(errors.As(err, &patternErr)).To(BeTrue(), fmt.Sprint(err))
+				}
+			})
+		})
+
+		When("using repetition count at the limit", func() {
+			It("should not return error", func() {
+				q := orm.NewQuery(nil, &RegexTestItem{})
+
+				_, err := pgquery.NewRegex("name").Pattern("a{255}").Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("using case insensitive similar to", func() {
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &RegexTestItem{})
+
+				_, err := pgquery.NewRegex("name").Similar().CaseInsensitive().Pattern("name%").Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*RegexTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &RegexTestItem{
+					Name: fmt.Sprintf("Name-%d", itemCount),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with case insensitive", func() {
+			var items []RegexTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRegex("name").CaseInsensitive().Pattern("^name-[1-3]$").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+
+		It("works with negated", func() {
+			var items []RegexTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRegex("name").Not().Pattern("^Name-1").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(8))
+		})
+
+		It("works with similar to", func() {
+			var items []RegexTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRegex("name").Similar().Pattern("Name-(2|4|6)%").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(3))
+		})
+	})
+})
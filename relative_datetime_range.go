@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +31,7 @@ type RelativeDateTimeRangeUnitOption struct {
 	Second      *int `json:"second,omitempty"`
 	Week        *int `json:"week,omitempty"`
 	Year        *int `json:"year,omitempty"`
+	format      unitFormat
 }
 
 func (o *RelativeDateTimeRangeUnitOption) buildValue(value int, plural, singular string) string {
@@ -107,14 +110,495 @@ func (o *RelativeDateTimeRangeUnitOption) build() string {
 	return strings.Join(units, " ")
 }
 
+// DurationError relative datetime range duration string is not a valid ISO 8601 duration or
+// interval literal.
+type DurationError struct {
+	Duration string
+	Err      error
+}
+
+// Error returns the error message naming the rejected duration.
+func (e *DurationError) Error() string {
+	return fmt.Sprintf("[RelativeDateTimeRange]: invalid duration %q: %v", e.Duration, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DurationError) Unwrap() error {
+	return e.Err
+}
+
+// unitFormat the form a unit option was received in, so that it is marshalled back the same way.
+type unitFormat int
+
+const (
+	unitFormatObject unitFormat = iota
+	unitFormatISO8601
+	unitFormatInterval
+)
+
+// UnmarshalJSON custom JSON unmarshaler. Accepts one key per unit, e.g. {"day":7,"hour":3}, an ISO
+// 8601 duration, e.g. "P1Y2M3DT4H", or an interval literal, e.g. "3 days 4 hours".
+func (o *RelativeDateTimeRangeUnitOption) UnmarshalJSON(b []byte) error {
+	type alias RelativeDateTimeRangeUnitOption
+
+	var m1 alias
+	var m2 string
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		*o = RelativeDateTimeRangeUnitOption(m1)
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		var parsed *RelativeDateTimeRangeUnitOption
+		if strings.HasPrefix(strings.TrimSpace(m2), "P") {
+			parsed, err = parseISO8601Duration(strings.TrimSpace(m2))
+		} else {
+			parsed, err = parseInterval(m2)
+		}
+		if err != nil {
+			return &DurationError{Duration: m2, Err: err}
+		}
+		*o = *parsed
+		return nil
+	}
+
+	return errors.New("[RelativeDateTimeRange]: unsupported unit option format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (o *RelativeDateTimeRangeUnitOption) MarshalJSON() ([]byte, error) {
+	type alias RelativeDateTimeRangeUnitOption
+
+	switch o.format {
+	case unitFormatISO8601:
+		return json.Marshal(o.buildISO8601())
+	case unitFormatInterval:
+		if v := o.build(); v != "" {
+			return json.Marshal(v)
+		}
+		return json.Marshal("0 seconds")
+	default:
+		return json.Marshal((*alias)(o))
+	}
+}
+
+// buildISO8601 returns the ISO 8601 duration, millenniums, centuries and decades are folded into
+// years, milliseconds and microseconds into fractional seconds.
+func (o *RelativeDateTimeRangeUnitOption) buildISO8601() string {
+	value := func(v *int) int {
+		if v != nil {
+			return *v
+		}
+		return 0
+	}
+	years := value(o.Year) + 10*value(o.Decade) + 100*value(o.Century) + 1000*value(o.Millennium)
+	micros := 1000*value(o.Millisecond) + value(o.Microsecond)
+
+	var b strings.Builder
+	b.WriteString("P")
+	for _, u := range []struct {
+		value      int
+		designator string
+	}{{years, "Y"}, {value(o.Month), "M"}, {value(o.Week), "W"}, {value(o.Day), "D"}} {
+		if u.value != 0 {
+			fmt.Fprintf(&b, "%d%s", u.value, u.designator)
+		}
+	}
+	hours, minutes, seconds := value(o.Hour), value(o.Minute), value(o.Second)
+	if hours != 0 || minutes != 0 || seconds != 0 || micros != 0 {
+		b.WriteString("T")
+		if hours != 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes != 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds != 0 || micros != 0 {
+			seconds += micros / 1000000
+			micros %= 1000000
+			if micros != 0 {
+				fmt.Fprintf(&b, "%d.%s", seconds, strings.TrimRight(fmt.Sprintf("%06d", micros), "0"))
+			} else {
+				fmt.Fprintf(&b, "%d", seconds)
+			}
+			b.WriteString("S")
+		}
+	}
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// parseFraction returns the number of microseconds of the fractional seconds digits, e.g. "5" is
+// 500000.
+func parseFraction(digits string) (int, error) {
+	if len(digits) > 6 {
+		return 0, fmt.Errorf("fractional seconds %q exceed microsecond precision", digits)
+	}
+	micros, err := strconv.Atoi(digits + strings.Repeat("0", 6-len(digits)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid fractional seconds %q", digits)
+	}
+	return micros, nil
+}
+
+// setFractionalSeconds sets the seconds, milliseconds and microseconds of the unit option.
+func (o *RelativeDateTimeRangeUnitOption) setFractionalSeconds(seconds, micros int) {
+	o.Second = &seconds
+	if millis := micros / 1000; millis != 0 {
+		o.Millisecond = &millis
+	}
+	if micros %= 1000; micros != 0 {
+		o.Microsecond = &micros
+	}
+}
+
+// parseISO8601Duration parses the ISO 8601 duration, e.g. "P1Y2M3DT4H" or "PT1.5S". Only the
+// seconds may have a fraction.
+func parseISO8601Duration(s string) (*RelativeDateTimeRangeUnitOption, error) {
+	o := &RelativeDateTimeRangeUnitOption{format: unitFormatISO8601}
+	if !strings.HasPrefix(s, "P") {
+		return nil, errors.New(`ISO 8601 duration must start with "P"`)
+	}
+	dateUnits := map[byte]**int{'Y': &o.Year, 'M': &o.Month, 'W': &o.Week, 'D': &o.Day}
+	timeUnits := map[byte]**int{'H': &o.Hour, 'M': &o.Minute}
+	dateOrder, timeOrder := "YMWD", "HMS"
+
+	inTime, seen, last := false, false, -1
+	for i := 1; i < len(s); {
+		if s[i] == 'T' {
+			if inTime {
+				return nil, errors.New(`duplicate time designator "T"`)
+			}
+			inTime, last = true, -1
+			i++
+			if i == len(s) {
+				return nil, errors.New(`missing time components after "T"`)
+			}
+			continue
+		}
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if start == i {
+			return nil, fmt.Errorf("missing number before %q at position %d", s[i], i)
+		}
+		number := s[start:i]
+		fraction := ""
+		if i < len(s) && (s[i] == '.' || s[i] == ',') {
+			i++
+			fractionStart := i
+			for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+				i++
+			}
+			fraction = s[fractionStart:i]
+			if fraction == "" {
+				return nil, fmt.Errorf("missing fraction digits at position %d", i)
+			}
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("missing designator after %q", s[start:])
+		}
+		designator := s[i]
+		i++
+
+		order := dateOrder
+		if inTime {
+			order = timeOrder
+		}
+		pos := strings.IndexByte(order, designator)
+		if pos < 0 {
+			if inTime {
+				return nil, fmt.Errorf("unknown time designator %q, expected one of H, M, S", designator)
+			}
+			return nil, fmt.Errorf("unknown date designator %q, expected one of Y, M, W, D (time components must follow \"T\")", designator)
+		}
+		if pos <= last {
+			return nil, fmt.Errorf("designator %q is duplicated or out of order", designator)
+		}
+		last = pos
+
+		value, err := strconv.Atoi(number)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", number)
+		}
+		switch {
+		case inTime && designator == 'S':
+			micros := 0
+			if fraction != "" {
+				if micros, err = parseFraction(fraction); err != nil {
+					return nil, err
+				}
+			}
+			o.setFractionalSeconds(value, micros)
+		case fraction != "":
+			return nil, fmt.Errorf("fraction is only supported for seconds, got %q", s[start:i])
+		case inTime:
+			*timeUnits[designator] = &value
+		default:
+			*dateUnits[designator] = &value
+		}
+		seen = true
+	}
+	if !seen {
+		return nil, errors.New("duration has no components")
+	}
+	return o, nil
+}
+
+// intervalField returns the unit option field of the interval literal unit (or abbreviation)
+// accepted by Postgres, nil when the unit is unknown.
+func (o *RelativeDateTimeRangeUnitOption) intervalField(unit string) **int {
+	switch unit {
+	case "millennium", "millenniums", "millennia":
+		return &o.Millennium
+	case "century", "centuries":
+		return &o.Century
+	case "decade", "decades":
+		return &o.Decade
+	case "year", "years", "yr", "yrs", "y":
+		return &o.Year
+	case "month", "months", "mon", "mons":
+		return &o.Month
+	case "week", "weeks", "w":
+		return &o.Week
+	case "day", "days", "d":
+		return &o.Day
+	case "hour", "hours", "hr", "hrs", "h":
+		return &o.Hour
+	case "minute", "minutes", "min", "mins", "m":
+		return &o.Minute
+	case "second", "seconds", "sec", "secs", "s":
+		return &o.Second
+	case "millisecond", "milliseconds", "msec", "msecs", "ms":
+		return &o.Millisecond
+	case "microsecond", "microseconds", "usec", "usecs", "us":
+		return &o.Microsecond
+	default:
+		return nil
+	}
+}
+
+// parseInterval parses the interval literal, e.g. "3 days 4 hours", "1 year 2 mons" or
+// "3 days 04:05:06". Negative quantities and the "ago" direction are rejected, the direction is
+// given by the ago or upcoming option instead.
+func parseInterval(s string) (*RelativeDateTimeRangeUnitOption, error) {
+	o := &RelativeDateTimeRangeUnitOption{format: unitFormatInterval}
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) > 0 && fields[0] == "@" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("duration has no components")
+	}
+	seen := map[**int]bool{}
+	set := func(field **int, value int, unit string) error {
+		if seen[field] {
+			return fmt.Errorf("unit %q is specified more than once", unit)
+		}
+		seen[field] = true
+		*field = &value
+		return nil
+	}
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if field == "ago" {
+			return nil, errors.New(`"ago" is not supported, use the ago or upcoming option instead`)
+		}
+		if strings.Contains(field, ":") {
+			parts := strings.Split(field, ":")
+			if len(parts) > 3 {
+				return nil, fmt.Errorf("invalid time %q, expected hh:mm[:ss]", field)
+			}
+			values := make([]int, 2)
+			for j, part := range parts[:2] {
+				v, err := strconv.Atoi(part)
+				if err != nil || v < 0 {
+					return nil, fmt.Errorf("invalid time %q, expected hh:mm[:ss]", field)
+				}
+				values[j] = v
+			}
+			if err := set(&o.Hour, values[0], "hour"); err != nil {
+				return nil, err
+			}
+			if err := set(&o.Minute, values[1], "minute"); err != nil {
+				return nil, err
+			}
+			if len(parts) == 3 {
+				whole, fraction := parts[2], ""
+				if dot := strings.IndexByte(whole, '.'); dot >= 0 {
+					whole, fraction = whole[:dot], whole[dot+1:]
+				}
+				seconds, err := strconv.Atoi(whole)
+				if err != nil || seconds < 0 {
+					return nil, fmt.Errorf("invalid time %q, expected hh:mm[:ss]", field)
+				}
+				micros := 0
+				if fraction != "" {
+					if micros, err = parseFraction(fraction); err != nil {
+						return nil, err
+					}
+				}
+				if seen[&o.Second] {
+					return nil, errors.New(`unit "second" is specified more than once`)
+				}
+				seen[&o.Second] = true
+				o.setFractionalSeconds(seconds, micros)
+			}
+			continue
+		}
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("expected a whole number, got %q", field)
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("negative quantity %q is not supported", field)
+		}
+		if i+1 == len(fields) {
+			return nil, fmt.Errorf("missing unit after %q", field)
+		}
+		i++
+		unit := o.intervalField(fields[i])
+		if unit == nil {
+			return nil, fmt.Errorf("unknown unit %q", fields[i])
+		}
+		if err := set(unit, value, fields[i]); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// RelativeDateTimePreset relative datetime range calendar period preset.
+type RelativeDateTimePreset string
+
+const (
+	// RelativeDateTimePresetToday today preset.
+	RelativeDateTimePresetToday RelativeDateTimePreset = "today"
+
+	// RelativeDateTimePresetYesterday yesterday preset.
+	RelativeDateTimePresetYesterday RelativeDateTimePreset = "yesterday"
+
+	// RelativeDateTimePresetThisWeek this week preset, the whole week including the upcoming days.
+	RelativeDateTimePresetThisWeek RelativeDateTimePreset = "this_week"
+
+	// RelativeDateTimePresetLastWeek last week preset.
+	RelativeDateTimePresetLastWeek RelativeDateTimePreset = "last_week"
+
+	// RelativeDateTimePresetMonthToDate month to date preset, up to the end of today.
+	RelativeDateTimePresetMonthToDate RelativeDateTimePreset = "month_to_date"
+
+	// RelativeDateTimePresetLastMonth last month preset.
+	RelativeDateTimePresetLastMonth RelativeDateTimePreset = "last_month"
+
+	// RelativeDateTimePresetQuarterToDate calendar quarter to date preset, up to the end of today.
+	RelativeDateTimePresetQuarterToDate RelativeDateTimePreset = "quarter_to_date"
+
+	// RelativeDateTimePresetLastQuarter last calendar quarter preset.
+	RelativeDateTimePresetLastQuarter RelativeDateTimePreset = "last_quarter"
+
+	// RelativeDateTimePresetYearToDate calendar year to date preset, up to the end of today.
+	RelativeDateTimePresetYearToDate RelativeDateTimePreset = "year_to_date"
+
+	// RelativeDateTimePresetLastFiscalYear last fiscal year preset.
+	RelativeDateTimePresetLastFiscalYear RelativeDateTimePreset = "last_fiscal_year"
+)
+
+// bounds returns the half-open [start, end) bounds of the preset period containing now, in the
+// location of now.
+func (p RelativeDateTimePreset) bounds(now time.Time, weekStart time.Weekday, fiscalStart time.Month) (time.Time, time.Time, error) {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+	week := today.AddDate(0, 0, -((int(now.Weekday()) - int(weekStart) + 7) % 7))
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	quarter := time.Date(now.Year(), now.Month()-(now.Month()-1)%3, 1, 0, 0, 0, 0, loc)
+	fiscalYear := time.Date(now.Year(), fiscalStart, 1, 0, 0, 0, 0, loc)
+	if fiscalYear.After(now) {
+		fiscalYear = fiscalYear.AddDate(-1, 0, 0)
+	}
+
+	switch p {
+	case RelativeDateTimePresetToday:
+		return today, tomorrow, nil
+	case RelativeDateTimePresetYesterday:
+		return today.AddDate(0, 0, -1), today, nil
+	case RelativeDateTimePresetThisWeek:
+		return week, week.AddDate(0, 0, 7), nil
+	case RelativeDateTimePresetLastWeek:
+		return week.AddDate(0, 0, -7), week, nil
+	case RelativeDateTimePresetMonthToDate:
+		return month, tomorrow, nil
+	case RelativeDateTimePresetLastMonth:
+		return month.AddDate(0, -1, 0), month, nil
+	case RelativeDateTimePresetQuarterToDate:
+		return quarter, tomorrow, nil
+	case RelativeDateTimePresetLastQuarter:
+		return quarter.AddDate(0, -3, 0), quarter, nil
+	case RelativeDateTimePresetYearToDate:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc), tomorrow, nil
+	case RelativeDateTimePresetLastFiscalYear:
+		return fiscalYear.AddDate(-1, 0, 0), fiscalYear, nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported preset %q", p)
+	}
+}
+
+func parseWeekday(v string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(v, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported weekday %q", v)
+}
+
+func parseMonth(v string) (time.Month, error) {
+	if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= 12 {
+		return time.Month(n), nil
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.EqualFold(v, m.String()) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported month %q", v)
+}
+
+// relativeDateTimeRangeMode which side(s) of the window around now the relative datetime range
+// selects.
+type relativeDateTimeRangeMode int
+
+const (
+	// relativeDateTimeRangeModeWithin between ago and upcoming (default).
+	relativeDateTimeRangeModeWithin relativeDateTimeRangeMode = iota
+	// relativeDateTimeRangeModeOlderThan before ago, the lower bound is open.
+	relativeDateTimeRangeModeOlderThan
+	// relativeDateTimeRangeModeFurtherThan after upcoming, the upper bound is open.
+	relativeDateTimeRangeModeFurtherThan
+	// relativeDateTimeRangeModeOutside before ago or after upcoming.
+	relativeDateTimeRangeModeOutside
+)
+
 // RelativeDateTimeRange relative datetime range common filter.
 type RelativeDateTimeRange struct {
-	column        string
+	column        sqlColumn
 	layouts       []string
 	marshalLayout string
+	location      *time.Location
+	weekStart     *time.Weekday
+	fiscalStart   time.Month
+	clock         Clock
+	serverNow     bool
+	columnType    string
+	mode          relativeDateTimeRangeMode
 	Ago           *RelativeDateTimeRangeUnitOption `json:"ago,omitempty"`
 	Upcoming      *RelativeDateTimeRangeUnitOption `json:"upcoming,omitempty"`
 	At            *time.Time                       `json:"at,omitempty"`
+	Preset        RelativeDateTimePreset           `json:"preset,omitempty"`
 }
 
 // MarshalJSON custom JSON marshaler.
@@ -122,10 +606,26 @@ func (f *RelativeDateTimeRange) MarshalJSON() ([]byte, error) {
 	type alias RelativeDateTimeRange
 
 	m1 := struct {
-		At string `json:"at,omitempty"`
+		At          string                           `json:"at,omitempty"`
+		Ago         *RelativeDateTimeRangeUnitOption `json:"ago,omitempty"`
+		Upcoming    *RelativeDateTimeRangeUnitOption `json:"upcoming,omitempty"`
+		OlderThan   *RelativeDateTimeRangeUnitOption `json:"olderThan,omitempty"`
+		FurtherThan *RelativeDateTimeRangeUnitOption `json:"furtherThan,omitempty"`
+		Outside     bool                             `json:"outside,omitempty"`
 		*alias
 	}{alias: (*alias)(f)}
 
+	switch f.mode {
+	case relativeDateTimeRangeModeOlderThan:
+		m1.OlderThan = f.Ago
+	case relativeDateTimeRangeModeFurtherThan:
+		m1.FurtherThan = f.Upcoming
+	case relativeDateTimeRangeModeOutside:
+		m1.Ago, m1.Upcoming, m1.Outside = f.Ago, f.Upcoming, true
+	default:
+		m1.Ago, m1.Upcoming = f.Ago, f.Upcoming
+	}
+
 	if f.At != nil {
 		if f.marshalLayout == "" {
 			return nil, errors.New("[RelativeDateTimeRange]: marshalLayout is not specified for marshal json")
@@ -141,7 +641,10 @@ func (f *RelativeDateTimeRange) UnmarshalJSON(b []byte) error {
 	type alias RelativeDateTimeRange
 
 	m1 := struct {
-		At string `json:"at,omitempty"`
+		At          string                           `json:"at,omitempty"`
+		OlderThan   *RelativeDateTimeRangeUnitOption `json:"olderThan,omitempty"`
+		FurtherThan *RelativeDateTimeRangeUnitOption `json:"furtherThan,omitempty"`
+		Outside     bool                             `json:"outside,omitempty"`
 		*alias
 	}{alias: (*alias)(f)}
 
@@ -151,9 +654,37 @@ func (f *RelativeDateTimeRange) UnmarshalJSON(b []byte) error {
 
 	err := json.Unmarshal(b, &m1)
 	if err != nil {
+		var durationErr *DurationError
+		if errors.As(err, &durationErr) {
+			return err
+		}
 		return errors.New("[RelativeDateTimeRange]: unsupported format when unmarshalling json")
 	}
 
+	if f.Preset != "" {
+		if _, _, err := f.Preset.bounds(time.Now(), time.Monday, time.January); err != nil {
+			return fmt.Errorf("[RelativeDateTimeRange]: %v", err)
+		}
+	}
+
+	if m1.OlderThan != nil {
+		if f.Ago != nil && f.Ago.build() != "" {
+			return errors.New("[RelativeDateTimeRange]: olderThan cannot be combined with ago")
+		}
+		f.Ago = m1.OlderThan
+		f.OlderThan()
+	}
+	if m1.FurtherThan != nil {
+		if f.Upcoming != nil && f.Upcoming.build() != "" {
+			return errors.New("[RelativeDateTimeRange]: furtherThan cannot be combined with upcoming")
+		}
+		f.Upcoming = m1.FurtherThan
+		f.FurtherThan()
+	}
+	if m1.Outside || (m1.OlderThan != nil && m1.FurtherThan != nil) {
+		f.Outside()
+	}
+
 	for _, layout := range f.layouts {
 		if f.At == nil && m1.At != "" {
 			at, err := time.Parse(layout, m1.At)
@@ -171,7 +702,7 @@ func (f *RelativeDateTimeRange) UnmarshalJSON(b []byte) error {
 // NewRelativeDateTimeRange initializes a new relative datetime filter.
 func NewRelativeDateTimeRange(column string, layouts ...string) *RelativeDateTimeRange {
 	f := &RelativeDateTimeRange{
-		column:        column,
+		column:        sqlColumn{name: column},
 		layouts:       append(layouts, time.RFC3339),
 		marshalLayout: time.RFC3339,
 	}
@@ -180,10 +711,6 @@ func NewRelativeDateTimeRange(column string, layouts ...string) *RelativeDateTim
 }
 
 func (f *RelativeDateTimeRange) init() {
-	if f.At == nil {
-		now := time.Now()
-		f.At = &now
-	}
 	if f.Ago == nil {
 		f.Ago = &RelativeDateTimeRangeUnitOption{}
 	}
@@ -194,7 +721,7 @@ func (f *RelativeDateTimeRange) init() {
 
 // Column sets the column for the relative datetime filter.
 func (f *RelativeDateTimeRange) Column(column string) *RelativeDateTimeRange {
-	f.column = column
+	f.column = sqlColumn{name: column}
 	return f
 }
 
@@ -210,6 +737,75 @@ func (f *RelativeDateTimeRange) MarshalLayout(layout string) *RelativeDateTimeRa
 	return f
 }
 
+// Location set the time zone the preset calendar periods are aligned to and the interval arithmetic
+// runs in (AT TIME ZONE), so that days and months follow daylight saving time changes. Defaults to
+// the location of now for presets and the database session time zone for timestamptz columns.
+func (f *RelativeDateTimeRange) Location(location *time.Location) *RelativeDateTimeRange {
+	f.location = location
+	return f
+}
+
+// WeekStart set the first day of the week for the week presets, defaults to Monday.
+func (f *RelativeDateTimeRange) WeekStart(weekday time.Weekday) *RelativeDateTimeRange {
+	f.weekStart = &weekday
+	return f
+}
+
+// FiscalYearStart set the first month of the fiscal year for the fiscal year presets, defaults to January.
+func (f *RelativeDateTimeRange) FiscalYearStart(month time.Month) *RelativeDateTimeRange {
+	f.fiscalStart = month
+	return f
+}
+
+// Clock set the clock providing now when At is not set, resolved each time the filter is applied.
+// Defaults to time.Now.
+func (f *RelativeDateTimeRange) Clock(clock Clock) *RelativeDateTimeRange {
+	f.clock = clock
+	return f
+}
+
+// ServerNow set now to be resolved by the database (now()) when At is not set, so that clock skew
+// between the application and the database does not matter.
+func (f *RelativeDateTimeRange) ServerNow() *RelativeDateTimeRange {
+	f.serverNow = true
+	return f
+}
+
+// Type set the column type (date, timestamp or timestamptz) the interval arithmetic runs in,
+// defaults to the SQL type of the query model field, otherwise timestamp.
+func (f *RelativeDateTimeRange) Type(columnType string) *RelativeDateTimeRange {
+	f.columnType = columnType
+	return f
+}
+
+// OlderThan set the relative datetime range to select before ago only, e.g. AgoDay(30).OlderThan()
+// selects older than 30 days.
+func (f *RelativeDateTimeRange) OlderThan() *RelativeDateTimeRange {
+	f.mode = relativeDateTimeRangeModeOlderThan
+	return f
+}
+
+// FurtherThan set the relative datetime range to select after upcoming only, e.g.
+// UpcomingWeek(2).FurtherThan() selects further out than 2 weeks.
+func (f *RelativeDateTimeRange) FurtherThan() *RelativeDateTimeRange {
+	f.mode = relativeDateTimeRangeModeFurtherThan
+	return f
+}
+
+// Outside set the relative datetime range to select outside of the window, before ago or after
+// upcoming.
+func (f *RelativeDateTimeRange) Outside() *RelativeDateTimeRange {
+	f.mode = relativeDateTimeRangeModeOutside
+	return f
+}
+
+// AsPreset set the calendar period preset, the range is the half-open period containing now instead
+// of the ago and upcoming offsets.
+func (f *RelativeDateTimeRange) AsPreset(preset RelativeDateTimePreset) *RelativeDateTimeRange {
+	f.Preset = preset
+	return f
+}
+
 // AgoCentury set century for ago.
 func (f *RelativeDateTimeRange) AgoCentury(value int) *RelativeDateTimeRange {
 	f.init()
@@ -378,26 +974,245 @@ func (f *RelativeDateTimeRange) UpcomingYear(value int) *RelativeDateTimeRange {
 	return f
 }
 
-// AsAt set value.
+// AsAt set value, pins the range to at instead of now.
 func (f *RelativeDateTimeRange) AsAt(at time.Time) *RelativeDateTimeRange {
 	f.At = &at
 	return f
 }
 
+// now returns At when set, otherwise the current time of the clock.
+func (f *RelativeDateTimeRange) now() time.Time {
+	switch {
+	case f.At != nil:
+		return *f.At
+	case f.clock != nil:
+		return f.clock.Now()
+	default:
+		return time.Now()
+	}
+}
+
+// buildAt returns the value the range is relative to, now() when resolved by the database.
+func (f *RelativeDateTimeRange) buildAt() interface{} {
+	if f.At == nil && f.serverNow {
+		return types.Safe("now()")
+	}
+	return f.now().Format(time.RFC3339Nano)
+}
+
+// dateTimeType returns the datetime column type of the SQL type, e.g. "timestamp with time zone" is
+// timestamptz, empty when the SQL type is not a datetime type.
+func dateTimeType(sqlType string) string {
+	sqlType = strings.ToLower(strings.TrimSpace(sqlType))
+	if i, j := strings.IndexByte(sqlType, '('), strings.IndexByte(sqlType, ')'); i >= 0 && j > i {
+		sqlType = strings.TrimSpace(sqlType[:i] + sqlType[j+1:])
+	}
+	switch sqlType {
+	case "date":
+		return "date"
+	case "timestamp", "timestamp without time zone":
+		return "timestamp"
+	case "timestamptz", "timestamp with time zone":
+		return "timestamptz"
+	default:
+		return ""
+	}
+}
+
+// columnTypeFor returns the column type, or the datetime type of the query model field.
+func (f *RelativeDateTimeRange) columnTypeFor(q *orm.Query) string {
+	if f.columnType != "" {
+		return f.columnType
+	}
+	model := q.TableModel()
+	if model == nil {
+		return ""
+	}
+	column := f.column.name
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	if field, ok := model.Table().FieldsMap[column]; ok {
+		return dateTimeType(field.SQLType)
+	}
+	return ""
+}
+
+// buildBound returns the range bound, at plus or minus the interval. On timestamptz columns the
+// arithmetic runs in timestamptz, on other columns in timestamp. With a location the arithmetic
+// runs on the wall clock of the location (AT TIME ZONE), e.g. 1 day before noon is noon of the
+// previous day even across daylight saving time changes.
+func (f *RelativeDateTimeRange) buildBound(at interface{}, columnType string, op string, interval string) interface{} {
+	cast := func(typ string) interface{} {
+		if _, ok := at.(types.Safe); ok && typ == "timestamptz" {
+			return at
+		}
+		return orm.SafeQuery("?::"+typ, at)
+	}
+	var zone string
+	if f.location != nil {
+		zone = f.location.String()
+	}
+
+	var value interface{}
+	switch {
+	case zone == "" && columnType == "timestamptz":
+		value = cast("timestamptz")
+	case zone == "":
+		value = cast("timestamp")
+	case columnType == "timestamptz" && interval == "":
+		return cast("timestamptz")
+	default:
+		value = orm.SafeQuery("(? AT TIME ZONE ?)", cast("timestamptz"), zone)
+	}
+	if interval != "" {
+		value = orm.SafeQuery("? "+op+" interval ?", value, interval)
+	}
+	if zone != "" && columnType == "timestamptz" {
+		value = orm.SafeQuery("(?) AT TIME ZONE ?", value, zone)
+	}
+	return value
+}
+
+// presetBounds returns the half-open bounds of the preset period.
+func (f *RelativeDateTimeRange) presetBounds() (interface{}, interface{}, error) {
+	if f.Ago.build() != "" || f.Upcoming.build() != "" {
+		return nil, nil, errors.New("[RelativeDateTimeRange]: preset cannot be combined with ago or upcoming")
+	}
+	if f.At == nil && f.serverNow {
+		return nil, nil, errors.New("[RelativeDateTimeRange]: preset cannot be resolved with server now")
+	}
+	now := f.now()
+	if f.location != nil {
+		now = now.In(f.location)
+	}
+	weekStart, fiscalStart := time.Monday, time.January
+	if f.weekStart != nil {
+		weekStart = *f.weekStart
+	}
+	if f.fiscalStart != 0 {
+		fiscalStart = f.fiscalStart
+	}
+	start, end, err := f.Preset.bounds(now, weekStart, fiscalStart)
+	if err != nil {
+		return nil, nil, fmt.Errorf("[RelativeDateTimeRange]: %v", err)
+	}
+	return start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano), nil
+}
+
 // Appender returns parameters for cond group appender.
 func (f *RelativeDateTimeRange) Appender() applyFn {
 	f.init()
 	return func(q *orm.Query) (*orm.Query, error) {
-		if ago := f.Ago.build(); ago != "" {
-			q.Where("? >= ?::timestamp - interval ?", types.Ident(f.column), f.At.Format(time.RFC3339Nano), ago)
-		} else {
-			q.Where("? >= ?::timestamp", types.Ident(f.column), f.At.Format(time.RFC3339Nano))
+		if f.columnType != "" && !dateTimeTypes[f.columnType] {
+			return q, fmt.Errorf("[RelativeDateTimeRange]: unsupported type %q", f.columnType)
+		}
+		if f.location == time.Local {
+			return q, errors.New("[RelativeDateTimeRange]: location must be a named time zone, e.g. \"Asia/Kuala_Lumpur\"")
 		}
-		if upcoming := f.Upcoming.build(); upcoming != "" {
-			q.Where("? <= ?::timestamp + interval ?", types.Ident(f.column), f.At.Format(time.RFC3339Nano), upcoming)
+		switch {
+		case f.mode == relativeDateTimeRangeModeOlderThan && f.Upcoming.build() != "":
+			return q, errors.New("[RelativeDateTimeRange]: olderThan cannot be combined with upcoming")
+		case f.mode == relativeDateTimeRangeModeFurtherThan && f.Ago.build() != "":
+			return q, errors.New("[RelativeDateTimeRange]: furtherThan cannot be combined with ago")
+		}
+
+		// The window is [lower, upper], or the half-open [lower, upper) of the preset period.
+		var lower, upper interface{}
+		lowerOp, upperOp := ">=", "<="
+		if f.Preset != "" {
+			var err error
+			if lower, upper, err = f.presetBounds(); err != nil {
+				return q, err
+			}
+			upperOp = "<"
 		} else {
-			q.Where("? <= ?::timestamp", types.Ident(f.column), f.At.Format(time.RFC3339Nano))
+			at, columnType := f.buildAt(), f.columnTypeFor(q)
+			lower = f.buildBound(at, columnType, "-", f.Ago.build())
+			upper = f.buildBound(at, columnType, "+", f.Upcoming.build())
+		}
+		// The complement of the window, e.g. before lower is "< lower".
+		outsideOps := map[string]string{">=": "<", "<=": ">", "<": ">="}
+
+		switch f.mode {
+		case relativeDateTimeRangeModeOlderThan:
+			q.Where("? ? ?", buildIdent(f.column), types.Safe(outsideOps[lowerOp]), lower)
+		case relativeDateTimeRangeModeFurtherThan:
+			q.Where("? ? ?", buildIdent(f.column), types.Safe(outsideOps[upperOp]), upper)
+		case relativeDateTimeRangeModeOutside:
+			q.Where("? ? ?", buildIdent(f.column), types.Safe(outsideOps[lowerOp]), lower)
+			q.WhereOr("? ? ?", buildIdent(f.column), types.Safe(outsideOps[upperOp]), upper)
+		default:
+			q.Where("? ? ?", buildIdent(f.column), types.Safe(lowerOp), lower)
+			q.Where("? ? ?", buildIdent(f.column), types.Safe(upperOp), upper)
 		}
 		return q, nil
 	}
 }
+
+// Apply applies the relative datetime filter to the query.
+func (f *RelativeDateTimeRange) Apply(q *orm.Query) (*orm.Query, error) {
+	var err error
+	q = q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+		q, err = f.Appender()(q)
+		return q, err
+	})
+	return q, err
+}
+
+// decodeValues decodes the JSON parameter value, a nil field is initialized with the default
+// layout and the layout tag option first.
+func (f *RelativeDateTimeRange) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	if len(f.layouts) <= 0 {
+		f.layouts = []string{time.RFC3339}
+		f.marshalLayout = time.RFC3339
+		if layout := opts.get("layout"); layout != "" {
+			f.Layout(layout)
+		}
+	}
+	f.init()
+	return decodeJSON(param, values, f)
+}
+
+func (f *RelativeDateTimeRange) isZero() bool {
+	return (f.Ago == nil || f.Ago.build() == "") && (f.Upcoming == nil || f.Upcoming.build() == "") && f.Preset == "" &&
+		f.mode == relativeDateTimeRangeModeWithin
+}
+
+func (f *RelativeDateTimeRange) bind(opts *tagOptions) error {
+	if err := opts.allow("layout", "tz", "weekStart", "fiscalStart", "serverNow", "type"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("type") {
+		f.Type(opts.get("type"))
+	}
+	if opts.has("serverNow") {
+		f.ServerNow()
+	}
+	if layout := opts.get("layout"); layout != "" {
+		f.Layout(layout)
+	}
+	if opts.has("tz") {
+		location, err := loadLocation(opts.get("tz"))
+		if err != nil {
+			return err
+		}
+		f.Location(location)
+	}
+	if opts.has("weekStart") {
+		weekday, err := parseWeekday(opts.get("weekStart"))
+		if err != nil {
+			return err
+		}
+		f.WeekStart(weekday)
+	}
+	if opts.has("fiscalStart") {
+		month, err := parseMonth(opts.get("fiscalStart"))
+		if err != nil {
+			return err
+		}
+		f.FiscalYearStart(month)
+	}
+	return nil
+}
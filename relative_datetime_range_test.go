@@ -6,6 +6,7 @@ package pgquery_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -23,6 +24,12 @@ var _ = Describe("RelativeDatetimeRange", func() {
 		CreatedAt time.Time `pg:"type:timestamp"` // Without timezone.
 	}
 
+	type RelativeDatetimeRangeTzTestItem struct {
+		Id        int64
+		Name      string
+		CreatedAt time.Time
+	}
+
 	Context("marshalling json", func() {
 		t, err := time.Parse("2006-01-02", "2021-01-15")
 		Expect(err).ToNot(HaveOccurred())
@@ -48,6 +55,44 @@ var _ = Describe("RelativeDatetimeRange", func() {
 				Expect(b).To(MatchJSON(`{"at":"12:00AM","ago":{},"upcoming":{}}`))
 			})
 		})
+
+		When("using one-sided mode", func() {
+			It("should marshal json successfully", func() {
+				for _, c := range []struct {
+					f *pgquery.RelativeDateTimeRange
+					v string
+				}{
+					{pgquery.NewRelativeDateTimeRange("").AsAt(t).AgoDay(30).OlderThan(), `{"at":"2021-01-15T00:00:00Z","olderThan":{"day":30}}`},
+					{pgquery.NewRelativeDateTimeRange("").AsAt(t).UpcomingWeek(2).FurtherThan(), `{"at":"2021-01-15T00:00:00Z","furtherThan":{"week":2}}`},
+					{pgquery.NewRelativeDateTimeRange("").AsAt(t).AgoDay(1).UpcomingDay(2).Outside(), `{"at":"2021-01-15T00:00:00Z","ago":{"day":1},"upcoming":{"day":2},"outside":true}`},
+				} {
+					b, err := json.Marshal(c.f)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(b).To(MatchJSON(c.v))
+				}
+			})
+		})
+
+		When("using duration strings", func() {
+			It("should marshal json in the same form as received", func() {
+				for _, v := range []string{
+					`{"at":"2021-01-15T00:00:00Z","ago":"P1Y2M3DT4H","upcoming":{"day":1}}`,
+					`{"at":"2021-01-15T00:00:00Z","ago":"PT1.5S","upcoming":"P2W"}`,
+					`{"at":"2021-01-15T00:00:00Z","ago":"3 days 4 hours","upcoming":"1 month"}`,
+				} {
+					f := pgquery.NewRelativeDateTimeRange("").AsAt(t)
+
+					err := json.Unmarshal([]byte(v), f)
+					Expect(err).ToNot(HaveOccurred(), v)
+
+					b, err := json.Marshal(f)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(b).To(MatchJSON(v))
+				}
+			})
+		})
 	})
 
 	Context("unmarshalling json", func() {
@@ -75,6 +120,89 @@ var _ = Describe("RelativeDatetimeRange", func() {
 				Expect(f).To(Equal(pgquery.NewRelativeDateTimeRange("", "2006-01-02").AsAt(t)))
 			})
 		})
+
+		When("preset is set", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewRelativeDateTimeRange("").AsAt(t)
+
+				err := json.Unmarshal([]byte(`{"preset":"last_month"}`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewRelativeDateTimeRange("").AsAt(t).AsPreset(pgquery.RelativeDateTimePresetLastMonth)))
+			})
+
+			It("should return error for unsupported preset", func() {
+				err := json.Unmarshal([]byte(`{"preset":"last_century"}`), pgquery.NewRelativeDateTimeRange(""))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("using one-sided mode", func() {
+			It("should unmarshal json successfully", func() {
+				for _, c := range []struct {
+					v string
+					f *pgquery.RelativeDateTimeRange
+				}{
+					{`{"olderThan":{"day":30}}`, pgquery.NewRelativeDateTimeRange("").AsAt(t).AgoDay(30).OlderThan()},
+					{`{"furtherThan":{"week":2}}`, pgquery.NewRelativeDateTimeRange("").AsAt(t).UpcomingWeek(2).FurtherThan()},
+					{`{"ago":{"day":1},"upcoming":{"day":2},"outside":true}`, pgquery.NewRelativeDateTimeRange("").AsAt(t).AgoDay(1).UpcomingDay(2).Outside()},
+					{`{"olderThan":{"day":1},"furtherThan":{"day":2}}`, pgquery.NewRelativeDateTimeRange("").AsAt(t).AgoDay(1).UpcomingDay(2).Outside()},
+				} {
+					f := pgquery.NewRelativeDateTimeRange("").AsAt(t)
+
+					err := json.Unmarshal([]byte(c.v), f)
+					Expect(err).ToNot(HaveOccurred(), c.v)
+
+					Expect(f).To(Equal(c.f), c.v)
+				}
+			})
+
+			It("should return error when combined with the same side", func() {
+				for _, v := range []string{`{"ago":{"day":1},"olderThan":{"day":30}}`, `{"upcoming":{"day":1},"furtherThan":{"day":30}}`} {
+					err := json.Unmarshal([]byte(v), pgquery.NewRelativeDateTimeRange(""))
+					Expect(err).To(HaveOccurred(), v)
+				}
+			})
+		})
+
+		When("using duration strings", func() {
+			It("should unmarshal json successfully", func() {
+				at := t
+				for _, t := range []struct {
+					v   string
+					ago string
+				}{
+					{`"P1Y2M3DT4H"`, "1 year 2 months 3 days 4 hours"},
+					{`"P2W"`, "2 weeks"},
+					{`"PT4H30M"`, "4 hours 30 minutes"},
+					{`"PT1.25S"`, "1 second 250 milliseconds"},
+					{`"3 days 4 hours"`, "3 days 4 hours"},
+					{`"1 yr 2 mons 5 mins"`, "1 year 2 months 5 minutes"},
+					{`"1 day 04:05:06.5"`, "1 day 4 hours 5 minutes 6 seconds 500 milliseconds"},
+				} {
+					f := pgquery.NewRelativeDateTimeRange("created_at").AsAt(at)
+
+					err := json.Unmarshal([]byte(`{"ago":`+t.v+`}`), f)
+					Expect(err).ToNot(HaveOccurred(), t.v)
+
+					q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+					q, err = f.Apply(q)
+					Expect(err).ToNot(HaveOccurred())
+
+					s := queryString(q)
+					Expect(s).To(HaveSuffix(`("created_at" >= '2021-01-15T00:00:00Z'::timestamp - interval '`+t.ago+`') AND ("created_at" <= '2021-01-15T00:00:00Z'::timestamp))`), t.v)
+				}
+			})
+
+			It("should return descriptive error for invalid duration", func() {
+				for _, v := range []string{`"P"`, `"PT"`, `"P1H"`, `"P1D2Y"`, `"PT1.5M"`, `"P1Y2"`, `"3 fortnights"`, `"3"`, `"-3 days"`, `"3 days ago"`, `"3 days 4 days"`} {
+					err := json.Unmarshal([]byte(`{"ago":`+v+`}`), pgquery.NewRelativeDateTimeRange(""))
+
+					var durationErr *pgquery.DurationError
+					Expect(errors.As(err, &durationErr)).To(BeTrue(), v)
+				}
+			})
+		})
 	})
 
 	Context("generating sql", func() {
@@ -90,26 +218,261 @@ var _ = Describe("RelativeDatetimeRange", func() {
 			s := queryString(q)
 			Expect(s).To(Equal(`SELECT "relative_datetime_range_test_item"."id", "relative_datetime_range_test_item"."name", "relative_datetime_range_test_item"."created_at" FROM "relative_datetime_range_test_items" AS "relative_datetime_range_test_item" WHERE (("created_at" >= '2021-01-15T00:00:00Z'::timestamp - interval '5 hours') AND ("created_at" <= '2021-01-15T00:00:00Z'::timestamp))`))
 		})
+
+		When("using one-sided mode", func() {
+			It("should generate correct SQL string", func() {
+				for _, c := range []struct {
+					f     *pgquery.RelativeDateTimeRange
+					where string
+				}{
+					{pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).AgoDay(30).OlderThan(), `(("created_at" < '2021-01-15T00:00:00Z'::timestamp - interval '30 days'))`},
+					{pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).UpcomingWeek(2).FurtherThan(), `(("created_at" > '2021-01-15T00:00:00Z'::timestamp + interval '2 weeks'))`},
+					{pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).AgoDay(1).UpcomingDay(2).Outside(), `(("created_at" < '2021-01-15T00:00:00Z'::timestamp - interval '1 day') OR ("created_at" > '2021-01-15T00:00:00Z'::timestamp + interval '2 days'))`},
+					{pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).AsPreset(pgquery.RelativeDateTimePresetToday).OlderThan(), `(("created_at" < '2021-01-15T00:00:00Z'))`},
+					{pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).AsPreset(pgquery.RelativeDateTimePresetToday).FurtherThan(), `(("created_at" >= '2021-01-16T00:00:00Z'))`},
+					{pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).AsPreset(pgquery.RelativeDateTimePresetToday).Outside(), `(("created_at" < '2021-01-15T00:00:00Z') OR ("created_at" >= '2021-01-16T00:00:00Z'))`},
+				} {
+					q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+					q, err := c.f.Apply(q)
+					Expect(err).ToNot(HaveOccurred())
+
+					s := queryString(q)
+					Expect(s).To(Equal(`SELECT "relative_datetime_range_test_item"."id", "relative_datetime_range_test_item"."name", "relative_datetime_range_test_item"."created_at" FROM "relative_datetime_range_test_items" AS "relative_datetime_range_test_item" WHERE ` + c.where))
+				}
+			})
+
+			It("should return error when combined with the other side", func() {
+				for _, f := range []*pgquery.RelativeDateTimeRange{
+					pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).AgoDay(30).UpcomingDay(1).OlderThan(),
+					pgquery.NewRelativeDateTimeRange("created_at").AsAt(t).AgoDay(1).UpcomingWeek(2).FurtherThan(),
+				} {
+					q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+					_, err := f.Apply(q)
+					Expect(err).To(HaveOccurred())
+				}
+			})
+		})
+
+		When("using clock", func() {
+			It("should resolve now when applied", func() {
+				now := t
+				f := pgquery.NewRelativeDateTimeRange("created_at").Clock(pgquery.ClockFunc(func() time.Time { return now })).AgoDay(1)
+
+				for _, at := range []string{"2021-01-15T00:00:00Z", "2021-01-16T00:00:00Z"} {
+					q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+					q, err := f.Apply(q)
+					Expect(err).ToNot(HaveOccurred())
+
+					s := queryString(q)
+					Expect(s).To(HaveSuffix(`WHERE (("created_at" >= '` + at + `'::timestamp - interval '1 day') AND ("created_at" <= '` + at + `'::timestamp))`))
+
+					now = now.AddDate(0, 0, 1)
+				}
+			})
+
+			It("should prefer at over clock", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+				q, err := pgquery.NewRelativeDateTimeRange("created_at").Clock(pgquery.ClockFunc(time.Now)).AsAt(t).ServerNow().Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(HaveSuffix(`WHERE (("created_at" >= '2021-01-15T00:00:00Z'::timestamp) AND ("created_at" <= '2021-01-15T00:00:00Z'::timestamp))`))
+			})
+		})
+
+		When("using server now", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+				q, err := pgquery.NewRelativeDateTimeRange("created_at").ServerNow().AgoHour(5).UpcomingDay(1).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(HaveSuffix(`WHERE (("created_at" >= now()::timestamp - interval '5 hours') AND ("created_at" <= now()::timestamp + interval '1 day'))`))
+			})
+
+			It("should return error when using preset", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+				_, err := pgquery.NewRelativeDateTimeRange("created_at").ServerNow().AsPreset(pgquery.RelativeDateTimePresetToday).Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("using column type", func() {
+			location, err := time.LoadLocation("America/New_York")
+			Expect(err).ToNot(HaveOccurred())
+
+			// Daylight saving time starts at 2021-03-14 02:00 in New York, the previous day is 23 hours long.
+			at := time.Date(2021, 3, 14, 12, 0, 0, 0, location)
+
+			It("should use the type of the model field", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTzTestItem{})
+
+				q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(at).AgoDay(1).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "relative_datetime_range_tz_test_item"."id", "relative_datetime_range_tz_test_item"."name", "relative_datetime_range_tz_test_item"."created_at" FROM "relative_datetime_range_tz_test_items" AS "relative_datetime_range_tz_test_item" WHERE (("created_at" >= '2021-03-14T12:00:00-04:00'::timestamptz - interval '1 day') AND ("created_at" <= '2021-03-14T12:00:00-04:00'::timestamptz))`))
+			})
+
+			It("should run the arithmetic in the location across daylight saving time", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTzTestItem{})
+
+				q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(at).Location(location).AgoDay(1).UpcomingMonth(1).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "relative_datetime_range_tz_test_item"."id", "relative_datetime_range_tz_test_item"."name", "relative_datetime_range_tz_test_item"."created_at" FROM "relative_datetime_range_tz_test_items" AS "relative_datetime_range_tz_test_item" WHERE (("created_at" >= (('2021-03-14T12:00:00-04:00'::timestamptz AT TIME ZONE 'America/New_York') - interval '1 day') AT TIME ZONE 'America/New_York') AND ("created_at" <= (('2021-03-14T12:00:00-04:00'::timestamptz AT TIME ZONE 'America/New_York') + interval '1 month') AT TIME ZONE 'America/New_York'))`))
+			})
+
+			It("should convert to the location wall clock for timestamp", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+				q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(at).Location(location).AgoDay(1).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(HaveSuffix(`WHERE (("created_at" >= ('2021-03-14T12:00:00-04:00'::timestamptz AT TIME ZONE 'America/New_York') - interval '1 day') AND ("created_at" <= ('2021-03-14T12:00:00-04:00'::timestamptz AT TIME ZONE 'America/New_York')))`))
+			})
+
+			It("should prefer the explicit type", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+				q, err := pgquery.NewRelativeDateTimeRange("created_at").Type("timestamptz").ServerNow().Location(location).AgoDay(1).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(HaveSuffix(`WHERE (("created_at" >= ((now() AT TIME ZONE 'America/New_York') - interval '1 day') AT TIME ZONE 'America/New_York') AND ("created_at" <= now()))`))
+			})
+
+			It("should return error for unsupported type or unnamed location", func() {
+				for _, f := range []*pgquery.RelativeDateTimeRange{
+					pgquery.NewRelativeDateTimeRange("created_at").Type("interval").AgoDay(1),
+					pgquery.NewRelativeDateTimeRange("created_at").Location(time.Local).AgoDay(1),
+				} {
+					q := orm.NewQuery(nil, &RelativeDatetimeRangeTzTestItem{})
+
+					_, err := f.Apply(q)
+					Expect(err).To(HaveOccurred())
+				}
+			})
+		})
+
+		When("using preset", func() {
+			location, err := time.LoadLocation("Asia/Kuala_Lumpur")
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wednesday 2021-02-17 20:00 UTC is Thursday 2021-02-18 04:00 in Kuala Lumpur.
+			at := time.Date(2021, 2, 17, 20, 0, 0, 0, time.UTC)
+
+			It("should generate half-open bounds in the location", func() {
+				for _, t := range []struct {
+					preset pgquery.RelativeDateTimePreset
+					start  string
+					end    string
+				}{
+					{pgquery.RelativeDateTimePresetToday, "2021-02-18", "2021-02-19"},
+					{pgquery.RelativeDateTimePresetYesterday, "2021-02-17", "2021-02-18"},
+					{pgquery.RelativeDateTimePresetThisWeek, "2021-02-15", "2021-02-22"},
+					{pgquery.RelativeDateTimePresetLastWeek, "2021-02-08", "2021-02-15"},
+					{pgquery.RelativeDateTimePresetMonthToDate, "2021-02-01", "2021-02-19"},
+					{pgquery.RelativeDateTimePresetLastMonth, "2021-01-01", "2021-02-01"},
+					{pgquery.RelativeDateTimePresetQuarterToDate, "2021-01-01", "2021-02-19"},
+					{pgquery.RelativeDateTimePresetLastQuarter, "2020-10-01", "2021-01-01"},
+					{pgquery.RelativeDateTimePresetYearToDate, "2021-01-01", "2021-02-19"},
+					{pgquery.RelativeDateTimePresetLastFiscalYear, "2019-04-01", "2020-04-01"},
+				} {
+					q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+					q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(at).Location(location).FiscalYearStart(time.April).AsPreset(t.preset).Apply(q)
+					Expect(err).ToNot(HaveOccurred())
+
+					s := queryString(q)
+					Expect(s).To(Equal(`SELECT "relative_datetime_range_test_item"."id", "relative_datetime_range_test_item"."name", "relative_datetime_range_test_item"."created_at" FROM "relative_datetime_range_test_items" AS "relative_datetime_range_test_item" WHERE (("created_at" >= '`+t.start+`T00:00:00+08:00') AND ("created_at" < '`+t.end+`T00:00:00+08:00'))`), string(t.preset))
+				}
+			})
+
+			It("should use the week start", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+				q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(at).Location(location).WeekStart(time.Sunday).AsPreset(pgquery.RelativeDateTimePresetLastWeek).Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "relative_datetime_range_test_item"."id", "relative_datetime_range_test_item"."name", "relative_datetime_range_test_item"."created_at" FROM "relative_datetime_range_test_items" AS "relative_datetime_range_test_item" WHERE (("created_at" >= '2021-02-07T00:00:00+08:00') AND ("created_at" < '2021-02-14T00:00:00+08:00'))`))
+			})
+
+			It("should return error when combined with ago", func() {
+				q := orm.NewQuery(nil, &RelativeDatetimeRangeTestItem{})
+
+				_, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(at).AgoDay(1).AsPreset(pgquery.RelativeDateTimePresetToday).Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 
 	Context("integration testing", func() {
-		err := db.Model((*RelativeDatetimeRangeTestItem)(nil)).CreateTable(&orm.CreateTableOptions{
-			Temp: true,
-		})
-		Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			requireDB()
 
-		for itemCount := 1; itemCount <= 10; itemCount++ {
-			item := &RelativeDatetimeRangeTestItem{
-				Name: fmt.Sprintf("name-%d", itemCount),
+			createTempTables((*RelativeDatetimeRangeTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &RelativeDatetimeRangeTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+				}
+				if itemCount%2 == 0 {
+					item.CreatedAt = testTime.Add(time.Duration(itemCount) * time.Hour)
+				} else {
+					item.CreatedAt = testTime.Add(-time.Duration(itemCount) * time.Hour)
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			if itemCount%2 == 0 {
-				item.CreatedAt = testTime.Add(time.Duration(itemCount) * time.Hour)
-			} else {
-				item.CreatedAt = testTime.Add(-time.Duration(itemCount) * time.Hour)
+
+			createTempTables((*RelativeDatetimeRangeTzTestItem)(nil))
+
+			// Hourly from 2021-03-13 08:30 to 17:30 in New York (13:30 to 22:30 UTC).
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &RelativeDatetimeRangeTzTestItem{
+					Name:      fmt.Sprintf("name-%d", itemCount),
+					CreatedAt: time.Date(2021, 3, 13, 12+itemCount, 30, 0, 0, time.UTC),
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
 			}
-			_, err = db.Model(item).Insert()
+		})
+
+		It("works with older than", func() {
+			var items []RelativeDatetimeRangeTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(testTime).AgoHour(5).OlderThan().Apply(q)
 			Expect(err).ToNot(HaveOccurred())
-		}
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(2))
+		})
+
+		It("works with outside", func() {
+			var items []RelativeDatetimeRangeTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(testTime).AgoHour(4).UpcomingHour(4).Outside().Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(6))
+		})
 
 		It("works with hour ago filter", func() {
 			var items []RelativeDatetimeRangeTestItem
@@ -214,5 +577,39 @@ var _ = Describe("RelativeDatetimeRange", func() {
 				}
 			}
 		})
+
+		It("works with preset", func() {
+			var items []RelativeDatetimeRangeTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewRelativeDateTimeRange("created_at").AsAt(testTime.Add(time.Hour)).AsPreset(pgquery.RelativeDateTimePresetYesterday).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(5)) {
+				for _, item := range items {
+					Expect(item.CreatedAt.Before(testTime)).To(BeTrue())
+				}
+			}
+		})
+
+		It("works with day ago across daylight saving time", func() {
+			location, err := time.LoadLocation("America/New_York")
+			Expect(err).ToNot(HaveOccurred())
+
+			var items []RelativeDatetimeRangeTzTestItem
+			q := db.Model(&items)
+
+			// 1 day before 2021-03-14 12:00 EDT is 2021-03-13 12:00 EST (17:00 UTC), 23 hours earlier.
+			q, err = pgquery.NewRelativeDateTimeRange("created_at").AsAt(time.Date(2021, 3, 14, 12, 0, 0, 0, location)).Location(location).AgoDay(1).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(items).To(HaveLen(6))
+		})
 	})
 })
@@ -0,0 +1,197 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// Operation field operation bit flag type.
+type Operation int
+
+const (
+	// OperationFilter operation filter, e.g. Match, Range.
+	OperationFilter Operation = 1 << iota
+
+	// OperationSort operation sort, e.g. Order, GeoDistance.
+	OperationSort
+
+	// OperationSearch operation search, e.g. KeywordSearch, FullTextSearch, Similarity, Regex.
+	OperationSearch
+)
+
+// String returns the string presentation for the operation(s).
+func (o Operation) String() string {
+	var names []string
+	for i, name := range []string{"filter", "sort", "search"} {
+		if o&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// operationOf returns the operation performed by the filter.
+func operationOf(f Filter) Operation {
+	switch f.(type) {
+	case *Order, *GeoDistance:
+		return OperationSort
+	case *KeywordSearch, *FullTextSearch, *Similarity, *Regex:
+		return OperationSearch
+	default:
+		return OperationFilter
+	}
+}
+
+// FieldError field is unknown or does not allow the operation.
+type FieldError struct {
+	Field     string
+	Operation Operation
+	Unknown   bool
+}
+
+// Error returns the error message naming the rejected field.
+func (e *FieldError) Error() string {
+	if e.Unknown {
+		return fmt.Sprintf("[Schema]: unknown field %q", e.Field)
+	}
+	return fmt.Sprintf("[Schema]: field %q does not allow %s", e.Field, e.Operation)
+}
+
+type schemaField struct {
+	column     sqlColumn
+	operations Operation
+}
+
+// Schema allowlist of public field names mapped to SQL columns or expressions. Fields received from
+// clients should be resolved through the schema before they are used as filter columns, bind
+// request structs with Schema.Bind and unmarshal filter groups with Schema.Factory.
+type Schema struct {
+	fields map[string]*schemaField
+}
+
+// NewSchema initializes a new schema.
+func NewSchema() *Schema {
+	return &Schema{
+		fields: make(map[string]*schemaField),
+	}
+}
+
+// Field registers the public field name mapped to the SQL column, e.g. "users.name".
+func (s *Schema) Field(name, column string, operations Operation) *Schema {
+	s.fields[name] = &schemaField{
+		column:     sqlColumn{name: column},
+		operations: operations,
+	}
+	return s
+}
+
+// Expr registers the public field name mapped to the raw SQL expression, e.g. "lower(users.name)".
+// The expression is only rendered unquoted for filters resolved through Sort or Factory.
+func (s *Schema) Expr(name, expr string, operations Operation) *Schema {
+	s.fields[name] = &schemaField{
+		column:     sqlColumn{name: "(" + expr + ")", expr: true},
+		operations: operations,
+	}
+	return s
+}
+
+// Resolve returns the SQL column (or expression) for the public field name, or a *FieldError when
+// the field is unknown or does not allow the operation. Columns set through a filter Column setter
+// are always quoted, use Bind, Sort or Factory to set filter columns from Expr fields.
+func (s *Schema) Resolve(name string, operation Operation) (string, error) {
+	column, err := s.resolve(name, operation)
+	return column.name, err
+}
+
+func (s *Schema) resolve(name string, operation Operation) (sqlColumn, error) {
+	field, ok := s.fields[name]
+	if !ok {
+		return sqlColumn{}, &FieldError{Field: name, Operation: operation, Unknown: true}
+	}
+	if field.operations&operation != operation {
+		return sqlColumn{}, &FieldError{Field: name, Operation: operation}
+	}
+	return field.column, nil
+}
+
+// Sort resolves the public field name of the order sorter(s) to SQL columns in place, e.g.
+// schema.Sort(sort.Orders...). The public name is kept for marshalling json and encoding.
+func (s *Schema) Sort(orders ...*Order) error {
+	for _, o := range orders {
+		if o == nil {
+			continue
+		}
+		column, err := s.resolve(o.column.name, OperationSort)
+		if err != nil {
+			return err
+		}
+		o.resolved = column
+	}
+	return nil
+}
+
+// Bind binds the filter fields of the request struct through the schema and applies them to the
+// query. See Schema.BindFilters.
+func (s *Schema) Bind(q *orm.Query, v interface{}) (*orm.Query, error) {
+	filters, err := s.BindFilters(v)
+	if err != nil {
+		return q, err
+	}
+	return ApplyAll(q, filters...)
+}
+
+// BindFilters binds the filter fields of the request struct like BindFilters, the parameter name
+// of each field is resolved through the schema for the operation performed by the filter. Order
+// and Sort keys are resolved with Sort, fields tagged with a column are not resolved. Groups are
+// not resolved, unmarshal them with Factory.
+func (s *Schema) BindFilters(v interface{}) ([]Filter, error) {
+	return bindFilters(v, s.resolveField)
+}
+
+func (s *Schema) resolveField(field reflect.StructField, f Filter, opts *tagOptions) error {
+	switch f := f.(type) {
+	case *Order:
+		return s.Sort(f)
+	case *Sort:
+		return s.Sort(f.Orders...)
+	case *Group, *Has, *OffsetPagination, *CursorPagination:
+		return nil
+	}
+	if _, ok := f.(binder); !ok || opts.column != "" {
+		return nil
+	}
+	column, err := s.resolve(paramName(field, opts), operationOf(f))
+	if err != nil {
+		return err
+	}
+	opts.column, opts.expr = column.name, column.expr
+	return nil
+}
+
+// Factory wraps the filter factory, resolves the field name for the operation performed by the
+// filter and sets the filter column. Use it to unmarshal filter groups received from clients.
+func (s *Schema) Factory(factory FilterFactory) FilterFactory {
+	return func(name string) (Filter, error) {
+		f, err := factory(name)
+		if err != nil || f == nil {
+			return f, err
+		}
+		column, err := s.resolve(name, operationOf(f))
+		if err != nil {
+			return nil, err
+		}
+		if b, ok := f.(binder); ok {
+			if err := b.bind(&tagOptions{column: column.name, expr: column.expr}); err != nil {
+				return nil, err
+			}
+		}
+		return f, nil
+	}
+}
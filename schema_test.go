@@ -0,0 +1,237 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema", func() {
+
+	type SchemaTestItem struct {
+		Id           int64
+		Name         string
+		Age          int
+		PasswordHash string
+	}
+
+	schema := pgquery.NewSchema().
+		Field("id", "schema_test_item.id", pgquery.OperationFilter|pgquery.OperationSort).
+		Field("age", "schema_test_item.age", pgquery.OperationFilter|pgquery.OperationSort).
+		Expr("name", "lower(schema_test_item.name)", pgquery.OperationSort|pgquery.OperationSearch)
+
+	factory := schema.Factory(func(field string) (pgquery.Filter, error) {
+		switch field {
+		case "name":
+			return pgquery.NewKeywordSearch(""), nil
+		case "id":
+			return pgquery.NewMatch(""), nil
+		default:
+			return pgquery.NewRange(""), nil
+		}
+	})
+
+	Context("resolving fields", func() {
+		It("should resolve allowed fields", func() {
+			column, err := schema.Resolve("age", pgquery.OperationSort)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(column).To(Equal("schema_test_item.age"))
+		})
+
+		When("field is unknown", func() {
+			It("should return field error", func() {
+				_, err := schema.Resolve("password_hash", pgquery.OperationSort)
+
+				var fieldErr *pgquery.FieldError
+				if Expect(errors.As(err, &fieldErr)).To(BeTrue()) {
+					Expect(fieldErr.Field).To(Equal("password_hash"))
+					Expect(fieldErr.Unknown).To(BeTrue())
+				}
+			})
+		})
+
+		When("field does not allow the operation", func() {
+			It("should return field error", func() {
+				_, err := schema.Resolve("name", pgquery.OperationFilter)
+
+				var fieldErr *pgquery.FieldError
+				if Expect(errors.As(err, &fieldErr)).To(BeTrue()) {
+					Expect(fieldErr.Field).To(Equal("name"))
+					Expect(fieldErr.Operation).To(Equal(pgquery.OperationFilter))
+					Expect(fieldErr.Unknown).To(BeFalse())
+				}
+			})
+		})
+
+		When("unmarshalling json", func() {
+			It("should reject fields that are not allowed", func() {
+				g := pgquery.And().Factory(factory)
+
+				err := json.Unmarshal([]byte(`{"or":[{"password_hash":{"gt":0}}]}`), g)
+
+				var fieldErr *pgquery.FieldError
+				Expect(errors.As(err, &fieldErr)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &SchemaTestItem{})
+
+			values, err := url.ParseQuery("sort=-name,id")
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &struct {
+				Sort []*pgquery.Order
+			}{}
+			err = pgquery.DecodeValues(values, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = schema.Sort(req.Sort...)
+			Expect(err).ToNot(HaveOccurred())
+
+			g := pgquery.And().Factory(factory)
+			err = json.Unmarshal([]byte(`{"or":[{"name":"name-1"},{"age":{"gt":5}}]}`), g)
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err = pgquery.ApplyAll(q, g)
+			Expect(err).ToNot(HaveOccurred())
+			q, err = pgquery.Bind(q, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "schema_test_item"."id", "schema_test_item"."name", "schema_test_item"."age", "schema_test_item"."password_hash" FROM "schema_test_items" AS "schema_test_item" WHERE ((((lower(schema_test_item.name)) LIKE '%name-1%')) OR ((("schema_test_item"."age" > 5)))) ORDER BY (lower(schema_test_item.name)) DESC, "schema_test_item"."id" ASC`))
+		})
+
+		It("should keep public sort fields when encoding", func() {
+			values, err := url.ParseQuery("sort=-name,id")
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &struct {
+				Sort *pgquery.Sort
+			}{}
+			err = pgquery.DecodeValues(values, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = schema.Sort(req.Sort.Orders...)
+			Expect(err).ToNot(HaveOccurred())
+
+			encoded, err := pgquery.EncodeValues(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded).To(Equal(values))
+
+			b, err := json.Marshal(req.Sort)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(b).To(MatchJSON(`[{"name":"DESC"},{"id":"ASC"}]`))
+
+			err = pgquery.DecodeValues(encoded, req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should bind request struct through the schema", func() {
+			q := orm.NewQuery(nil, &SchemaTestItem{})
+
+			values, err := url.ParseQuery("name=name-1&age[gt]=5&sort=-name")
+			Expect(err).ToNot(HaveOccurred())
+
+			req := &struct {
+				Name *pgquery.KeywordSearch
+				Age  *pgquery.Range
+				Sort *pgquery.Sort
+			}{}
+			err = pgquery.DecodeValues(values, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err = schema.Bind(q, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "schema_test_item"."id", "schema_test_item"."name", "schema_test_item"."age", "schema_test_item"."password_hash" FROM "schema_test_items" AS "schema_test_item" WHERE ((lower(schema_test_item.name)) LIKE '%name-1%') AND (("schema_test_item"."age" > 5)) ORDER BY (lower(schema_test_item.name)) DESC`))
+		})
+
+		When("binding fields that are not allowed", func() {
+			It("should return field error", func() {
+				for _, v := range []string{"sort=password_hash", "password_hash=secret"} {
+					values, err := url.ParseQuery(v)
+					Expect(err).ToNot(HaveOccurred())
+
+					req := &struct {
+						PasswordHash *pgquery.Match
+						Sort         *pgquery.Sort
+					}{}
+					err = pgquery.DecodeValues(values, req)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = schema.BindFilters(req)
+
+					var fieldErr *pgquery.FieldError
+					if Expect(errors.As(err, &fieldErr)).To(BeTrue(), v) {
+						Expect(fieldErr.Field).To(Equal("password_hash"))
+					}
+				}
+			})
+		})
+
+		When("sort field is not allowed", func() {
+			It("should return field error before building sql", func() {
+				err := schema.Sort(pgquery.NewOrderAsc("password_hash"))
+
+				var fieldErr *pgquery.FieldError
+				Expect(errors.As(err, &fieldErr)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*SchemaTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &SchemaTestItem{
+					Name:         fmt.Sprintf("NAME-%d", itemCount),
+					Age:          itemCount,
+					PasswordHash: "secret",
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with expression field", func() {
+			var items []SchemaTestItem
+			q := db.Model(&items)
+
+			g := pgquery.And().Factory(factory)
+			err := json.Unmarshal([]byte(`{"name":"name-1"}`), g)
+			Expect(err).ToNot(HaveOccurred())
+
+			o := pgquery.NewOrderDesc("age")
+			err = schema.Sort(o)
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err = pgquery.ApplyAll(q, g, o)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(2)) {
+				Expect(items[0].Name).To(Equal("NAME-10"))
+				Expect(items[1].Name).To(Equal("NAME-1"))
+			}
+		})
+	})
+})
@@ -0,0 +1,172 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// Similarity trigram similarity common filter, requires the pg_trgm extension.
+type Similarity struct {
+	column    sqlColumn
+	word      bool
+	threshold *float64
+	rank      bool
+	Value     *string `json:"value,omitempty"`
+}
+
+// UnmarshalJSON custom JSON unmarshaler.
+func (f *Similarity) UnmarshalJSON(b []byte) error {
+	type alias Similarity
+
+	m1 := alias{}
+	var m2 *string
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		f.Value = m1.Value
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		f.Value = m2
+		return nil
+	}
+
+	return errors.New("[Similarity]: unsupported format when unmarshalling json")
+}
+
+// MarshalJSON custom JSON marshaler.
+func (f *Similarity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Value)
+}
+
+// NewSimilarity initializes a new similarity filter.
+func NewSimilarity(column string) *Similarity {
+	return &Similarity{
+		column: sqlColumn{name: column},
+	}
+}
+
+// Column set the column for the similarity filter.
+func (f *Similarity) Column(column string) *Similarity {
+	f.column = sqlColumn{name: column}
+	return f
+}
+
+// Word set word similarity for the similarity filter, the value is compared with the most
+// similar part of the column instead of the whole column.
+func (f *Similarity) Word() *Similarity {
+	f.word = true
+	return f
+}
+
+// Threshold set the similarity threshold, between 0 and 1, for the similarity filter. The
+// pg_trgm.similarity_threshold (or pg_trgm.word_similarity_threshold) setting is used when not
+// set, which also allows the operator to use trigram indexes.
+func (f *Similarity) Threshold(threshold float64) *Similarity {
+	f.threshold = &threshold
+	return f
+}
+
+// Rank set order by similarity descending for the similarity filter.
+func (f *Similarity) Rank() *Similarity {
+	f.rank = true
+	return f
+}
+
+// Keyword set value.
+func (f *Similarity) Keyword(keyword string) *Similarity {
+	f.Value = &keyword
+	return f
+}
+
+func (f *Similarity) buildValue() string {
+	if f.Value != nil {
+		return *f.Value
+	}
+	return ""
+}
+
+// buildSimilarity returns the similarity function of the value and column.
+func (f *Similarity) buildSimilarity() interface{} {
+	if f.word {
+		return orm.SafeQuery("word_similarity(?, ?)", f.buildValue(), buildIdent(f.column))
+	}
+	return orm.SafeQuery("similarity(?, ?)", buildIdent(f.column), f.buildValue())
+}
+
+// Appender returns parameters for cond appender.
+func (f *Similarity) Appender() (string, interface{}, interface{}) {
+	switch {
+	case f.threshold != nil:
+		return "? >= ?", f.buildSimilarity(), *f.threshold
+	case f.word:
+		return "? <% ?", f.buildValue(), buildIdent(f.column)
+	default:
+		return "? % ?", buildIdent(f.column), f.buildValue()
+	}
+}
+
+// RankAppender returns parameters for order appender, ranks rows by descending similarity.
+func (f *Similarity) RankAppender() (string, interface{}) {
+	return "? DESC", f.buildSimilarity()
+}
+
+// Apply applies the similarity filter to the query.
+func (f *Similarity) Apply(q *orm.Query) (*orm.Query, error) {
+	if t := f.threshold; t != nil && (*t < 0 || *t > 1) {
+		return q, fmt.Errorf("[Similarity]: threshold %v is not between 0 and 1", *t)
+	}
+	q.Where(f.Appender())
+	if f.rank {
+		q.OrderExpr(f.RankAppender())
+	}
+	return q, nil
+}
+
+func (f *Similarity) isZero() bool {
+	return f.Value == nil
+}
+
+func (f *Similarity) bind(opts *tagOptions) error {
+	if err := opts.allow("word", "threshold", "rank"); err != nil {
+		return err
+	}
+	f.column = opts.columnFor(f.column)
+	if opts.has("word") {
+		f.Word()
+	}
+	if opts.has("threshold") {
+		threshold, err := strconv.ParseFloat(opts.get("threshold"), 64)
+		if err != nil {
+			return fmt.Errorf("invalid threshold %q", opts.get("threshold"))
+		}
+		f.Threshold(threshold)
+	}
+	if opts.has("rank") {
+		f.Rank()
+	}
+	return nil
+}
+
+func (f *Similarity) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	v, ok := paramValue(values, param)
+	if !ok {
+		return false, nil
+	}
+	f.Keyword(v)
+	return true, nil
+}
+
+func (f *Similarity) encodeValues(param string, values url.Values) error {
+	values.Set(param, *f.Value)
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Similarity", func() {
+
+	type SimilarityTestItem struct {
+		Id   int64
+		Name string
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			f := pgquery.NewSimilarity("").Keyword("keyword")
+
+			b, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`"keyword"`))
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		When("using object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewSimilarity("")
+
+				err := json.Unmarshal([]byte(`{"value":"keyword"}`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewSimilarity("").Keyword("keyword")))
+			})
+		})
+
+		When("using non-object syntax", func() {
+			It("should unmarshal json successfully", func() {
+				f := pgquery.NewSimilarity("")
+
+				err := json.Unmarshal([]byte(`"keyword"`), f)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(f).To(Equal(pgquery.NewSimilarity("").Keyword("keyword")))
+			})
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &SimilarityTestItem{})
+
+			q.Where(pgquery.NewSimilarity("name").Keyword("jonh").Appender())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "similarity_test_item"."id", "similarity_test_item"."name" FROM "similarity_test_items" AS "similarity_test_item" WHERE ("name" % 'jonh')`))
+		})
+
+		When("using word similarity", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &SimilarityTestItem{})
+
+				q.Where(pgquery.NewSimilarity("name").Word().Keyword("jonh").Appender())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "similarity_test_item"."id", "similarity_test_item"."name" FROM "similarity_test_items" AS "similarity_test_item" WHERE ('jonh' <% "name")`))
+			})
+		})
+
+		When("using threshold and rank", func() {
+			It("should generate correct SQL string", func() {
+				q := orm.NewQuery(nil, &SimilarityTestItem{})
+
+				q, err := pgquery.NewSimilarity("name").Threshold(0.4).Rank().Keyword("jonh").Apply(q)
+				Expect(err).ToNot(HaveOccurred())
+
+				s := queryString(q)
+				Expect(s).To(Equal(`SELECT "similarity_test_item"."id", "similarity_test_item"."name" FROM "similarity_test_items" AS "similarity_test_item" WHERE (similarity("name", 'jonh') >= 0.4) ORDER BY similarity("name", 'jonh') DESC`))
+			})
+		})
+
+		When("using invalid threshold", func() {
+			It("should return error", func() {
+				q := orm.NewQuery(nil, &SimilarityTestItem{})
+
+				_, err := pgquery.NewSimilarity("name").Threshold(1.5).Keyword("jonh").Apply(q)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireExtension("pg_trgm")
+
+			createTempTables((*SimilarityTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				name := fmt.Sprintf("item-%d", itemCount)
+				switch itemCount {
+				case 3:
+					name = "John Smith"
+				case 7:
+					name = "Jon"
+				}
+				item := &SimilarityTestItem{
+					Name: name,
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with default search", func() {
+			var items []SimilarityTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewSimilarity("name").Rank().Keyword("Jonh").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(1)) {
+				Expect(items[0].Id).To(Equal(int64(7)))
+			}
+		})
+
+		It("works with word similarity threshold", func() {
+			var items []SimilarityTestItem
+			q := db.Model(&items)
+
+			q, err := pgquery.NewSimilarity("name").Word().Threshold(0.5).Rank().Keyword("Smth").Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			if Expect(items).To(HaveLen(1)) {
+				Expect(items[0].Id).To(Equal(int64(3)))
+			}
+		})
+	})
+})
@@ -0,0 +1,250 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// DefaultSortMaxKeys default maximum number of sort keys.
+const DefaultSortMaxKeys = 5
+
+// Sort multi-column common sorter, holds an ordered list of order sorters.
+type Sort struct {
+	maxKeys int
+	Orders  []*Order
+}
+
+// MarshalJSON custom JSON marshaler, e.g. [{"created_at":"DESC"},{"name":"ASC"}].
+func (s *Sort) MarshalJSON() ([]byte, error) {
+	items := make([]map[string]*Order, 0, len(s.Orders))
+	for _, o := range s.Orders {
+		items = append(items, map[string]*Order{o.column.name: o})
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON custom JSON unmarshaler, accepts the "-created_at,name" string syntax or an array
+// of single key objects or strings, e.g. [{"created_at":"desc"},"name"]. The number of sort keys is
+// checked when the sorter is bound or applied.
+func (s *Sort) UnmarshalJSON(b []byte) error {
+	var m1 string
+	var m2 []json.RawMessage
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		return s.Parse(m1)
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		orders := make([]*Order, 0, len(m2))
+		for _, item := range m2 {
+			o, err := unmarshalSortItem(item)
+			if err != nil {
+				return err
+			}
+			orders = append(orders, o)
+		}
+		s.Orders = nil
+		s.Add(orders...)
+		return nil
+	}
+
+	return errors.New("[Sort]: unsupported format when unmarshalling json")
+}
+
+func unmarshalSortItem(b []byte) (*Order, error) {
+	var m1 string
+	var m2 map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &m1); err == nil {
+		o := NewOrder("")
+		if err := o.parse(m1); err != nil {
+			return nil, fmt.Errorf("[Sort]: %v", err)
+		}
+		return o, nil
+	}
+
+	if err := json.Unmarshal(b, &m2); err == nil {
+		if len(m2) != 1 {
+			return nil, errors.New("[Sort]: expected a single column per sort item")
+		}
+		for column, v := range m2 {
+			if !identRegexp.MatchString(column) {
+				return nil, fmt.Errorf("[Sort]: invalid sort column %q", column)
+			}
+			o := NewOrder(column)
+			if err := json.Unmarshal(v, o); err != nil {
+				return nil, err
+			}
+			return o, nil
+		}
+	}
+
+	return nil, errors.New("[Sort]: unsupported sort item format when unmarshalling json")
+}
+
+// NewSort initializes a new sort sorter.
+func NewSort(orders ...*Order) *Sort {
+	s := &Sort{}
+	return s.Add(orders...)
+}
+
+// ParseSort parses the "-created_at,name" string syntax into a new sort sorter.
+func ParseSort(v string) (*Sort, error) {
+	s := NewSort()
+	if err := s.Parse(v); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MaxKeys sets the maximum number of sort keys, defaults to DefaultSortMaxKeys. Negative value means
+// unlimited.
+func (s *Sort) MaxKeys(maxKeys int) *Sort {
+	s.maxKeys = maxKeys
+	return s
+}
+
+// Add appends the order sorter(s), columns that are already sorted are ignored.
+func (s *Sort) Add(orders ...*Order) *Sort {
+	for _, o := range orders {
+		if o != nil && !s.has(o.column.name) {
+			s.Orders = append(s.Orders, o)
+		}
+	}
+	return s
+}
+
+func (s *Sort) has(column string) bool {
+	for _, o := range s.Orders {
+		if o.column.name == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sort) validate() error {
+	maxKeys := s.maxKeys
+	if maxKeys == 0 {
+		maxKeys = DefaultSortMaxKeys
+	}
+	if maxKeys > 0 && len(s.Orders) > maxKeys {
+		return fmt.Errorf("too many sort keys, expected at most %d", maxKeys)
+	}
+	return nil
+}
+
+// Parse parses the "-created_at,name" string syntax, replacing the current order sorter(s). Each
+// column may be followed by ":nulls_first" or ":nulls_last". The number of sort keys is checked
+// when the sorter is bound or applied.
+func (s *Sort) Parse(v string) error {
+	if err := s.parse(v); err != nil {
+		return fmt.Errorf("[Sort]: %v", err)
+	}
+	return nil
+}
+
+func (s *Sort) parse(v string) error {
+	var orders []*Order
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		o := NewOrder("")
+		if err := o.parse(item); err != nil {
+			return err
+		}
+		orders = append(orders, o)
+	}
+	s.Orders = nil
+	s.Add(orders...)
+	return nil
+}
+
+// String returns the "-created_at,name" string syntax of the sort sorter.
+func (s *Sort) String() string {
+	items := make([]string, 0, len(s.Orders))
+	for _, o := range s.Orders {
+		items = append(items, o.format())
+	}
+	return strings.Join(items, ",")
+}
+
+// Appender returns parameters for query appender.
+func (s *Sort) Appender() applyFn {
+	return func(q *orm.Query) (*orm.Query, error) {
+		for _, o := range s.Orders {
+			q.OrderExpr(o.Appender())
+		}
+		return q, nil
+	}
+}
+
+// Apply applies the sort sorter to the query, returns error when there are too many sort keys.
+func (s *Sort) Apply(q *orm.Query) (*orm.Query, error) {
+	if err := s.validate(); err != nil {
+		return q, fmt.Errorf("[Sort]: %v", err)
+	}
+	return q.Apply(s.Appender()), nil
+}
+
+func (s *Sort) isZero() bool {
+	return len(s.Orders) == 0
+}
+
+func (s *Sort) bind(opts *tagOptions) error {
+	if err := opts.allow("max"); err != nil {
+		return err
+	}
+	if opts.column != "" {
+		return errors.New("column is not supported, columns are taken from the sort keys")
+	}
+	if err := s.bindMaxKeys(opts); err != nil {
+		return err
+	}
+	return s.validate()
+}
+
+func (s *Sort) bindMaxKeys(opts *tagOptions) error {
+	if !opts.has("max") {
+		return nil
+	}
+	maxKeys, err := strconv.Atoi(opts.get("max"))
+	if err != nil {
+		return fmt.Errorf("invalid max %q", opts.get("max"))
+	}
+	s.maxKeys = maxKeys
+	return nil
+}
+
+func (s *Sort) decodeValues(param string, values url.Values, opts *tagOptions) (bool, error) {
+	list := paramList(values, param)
+	if len(list) == 0 {
+		return false, nil
+	}
+	if err := s.bindMaxKeys(opts); err != nil {
+		return false, err
+	}
+	if err := s.parse(strings.Join(list, ",")); err != nil {
+		return false, decodeError(param, err)
+	}
+	if err := s.validate(); err != nil {
+		return false, decodeError(param, err)
+	}
+	return true, nil
+}
+
+func (s *Sort) encodeValues(param string, values url.Values) error {
+	values.Set(param, s.String())
+	return nil
+}
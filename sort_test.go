@@ -0,0 +1,201 @@
+// Copyright (c) KwanJunWen
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree.
+
+package pgquery_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/junwen-k/pgquery"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sort", func() {
+
+	type SortTestItem struct {
+		Id   int64
+		Name string
+		Age  *int
+	}
+
+	Context("marshalling json", func() {
+		It("should marshal json successfully", func() {
+			s := pgquery.NewSort(pgquery.NewOrderDesc("created_at"), pgquery.NewOrderAsc("name").NullsFirst())
+
+			b, err := json.Marshal(s)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(b).To(MatchJSON(`[{"created_at":"DESC"},{"name":"ASC NULLS FIRST"}]`))
+		})
+	})
+
+	Context("unmarshalling json", func() {
+		When("using string syntax", func() {
+			It("should unmarshal json successfully", func() {
+				s := pgquery.NewSort()
+
+				err := json.Unmarshal([]byte(`"-created_at,name:nulls_last"`), s)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(s).To(Equal(pgquery.NewSort(pgquery.NewOrderDesc("created_at"), pgquery.NewOrderAsc("name").NullsLast())))
+			})
+		})
+
+		When("using array syntax", func() {
+			It("should unmarshal json successfully", func() {
+				s := pgquery.NewSort()
+
+				err := json.Unmarshal([]byte(`[{"created_at":"desc"},{"age":{"direction":"asc","nulls":"first"}},"-name"]`), s)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(s).To(Equal(pgquery.NewSort(pgquery.NewOrderDesc("created_at"), pgquery.NewOrderAsc("age").NullsFirst(), pgquery.NewOrderDesc("name"))))
+			})
+		})
+
+		When("using duplicated columns", func() {
+			It("should keep the first sort key", func() {
+				s := pgquery.NewSort()
+
+				err := json.Unmarshal([]byte(`"-name,age,name"`), s)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(s).To(Equal(pgquery.NewSort(pgquery.NewOrderDesc("name"), pgquery.NewOrderAsc("age"))))
+			})
+		})
+
+		When("exceeding the maximum number of sort keys", func() {
+			It("should return error when applied", func() {
+				s := pgquery.NewSort().MaxKeys(2)
+
+				err := json.Unmarshal([]byte(`"a,b,c"`), s)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = s.Apply(orm.NewQuery(nil, &SortTestItem{}))
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should use the maximum from the struct tag", func() {
+				req := struct {
+					Sort *pgquery.Sort `pgquery:"max=10"`
+				}{}
+
+				err := json.Unmarshal([]byte(`{"sort":"a,b,c,d,e,f,g"}`), &req)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pgquery.Bind(orm.NewQuery(nil, &SortTestItem{}), &req)
+				Expect(err).ToNot(HaveOccurred())
+
+				req.Sort = nil
+				err = json.Unmarshal([]byte(`{"sort":"a,b,c,d,e,f,g,h,i,j,k"}`), &req)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pgquery.Bind(orm.NewQuery(nil, &SortTestItem{}), &req)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("using invalid values", func() {
+			It("should return error", func() {
+				for _, v := range []string{
+					`"name;drop"`,
+					`"name:nulls_middle"`,
+					`[{"name":"asc","age":"desc"}]`,
+					`[{"name drop":"asc"}]`,
+					`1`,
+				} {
+					err := json.Unmarshal([]byte(v), pgquery.NewSort())
+					Expect(err).To(HaveOccurred(), v)
+				}
+			})
+		})
+	})
+
+	Context("parsing string syntax", func() {
+		It("should format back to string syntax", func() {
+			s, err := pgquery.ParseSort("-created_at, name:nulls_first")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(s.String()).To(Equal("-created_at,name:nulls_first"))
+		})
+
+		It("should decode and encode query string values", func() {
+			req := struct {
+				Sort *pgquery.Sort `pgquery:"max=2"`
+			}{}
+
+			values, err := url.ParseQuery("sort=-age:nulls_last&sort=name")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Sort.Orders).To(Equal([]*pgquery.Order{pgquery.NewOrderDesc("age").NullsLast(), pgquery.NewOrderAsc("name")}))
+
+			encoded, err := pgquery.EncodeValues(&req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoded.Encode()).To(Equal("sort=-age%3Anulls_last%2Cname"))
+
+			values, err = url.ParseQuery("sort=age,name,id")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pgquery.DecodeValues(values, &req)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("generating sql", func() {
+		It("should generate correct SQL string", func() {
+			q := orm.NewQuery(nil, &SortTestItem{})
+
+			q, err := pgquery.NewSort(pgquery.NewOrderDesc("age").NullsLast(), pgquery.NewOrderAsc("name")).Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			s := queryString(q)
+			Expect(s).To(Equal(`SELECT "sort_test_item"."id", "sort_test_item"."name", "sort_test_item"."age" FROM "sort_test_items" AS "sort_test_item" ORDER BY "age" DESC NULLS LAST, "name" ASC`))
+		})
+	})
+
+	Context("integration testing", func() {
+		BeforeEach(func() {
+			requireDB()
+
+			createTempTables((*SortTestItem)(nil))
+
+			for itemCount := 1; itemCount <= 10; itemCount++ {
+				item := &SortTestItem{
+					Name: fmt.Sprintf("name-%d", itemCount),
+				}
+				if itemCount > 2 {
+					age := itemCount % 2
+					item.Age = &age
+				}
+				_, err := db.Model(item).Insert()
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("works with multiple columns and nulls position", func() {
+			var items []SortTestItem
+			q := db.Model(&items)
+
+			s, err := pgquery.ParseSort("-age:nulls_last,-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			q, err = s.Apply(q)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = q.Select()
+			Expect(err).ToNot(HaveOccurred())
+
+			ids := make([]int64, 0, len(items))
+			for _, item := range items {
+				ids = append(ids, item.Id)
+			}
+			Expect(ids).To(Equal([]int64{9, 7, 5, 3, 10, 8, 6, 4, 2, 1}))
+		})
+	})
+})